@@ -1,7 +1,9 @@
 package foundation
 
 import (
+	"fmt"
 	"net/http"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -52,12 +54,38 @@ func NewApplication(basePath string) Application {
 }
 
 // Run starts a http server running by calling http.ListenAndServe. It uses the host and port
-// set in the app.json config.
+// set in the app.json config. If the application was started by the gimli routes command the
+// registered routes are printed instead. Run panics if any routes conflict or route controllers
+// can not be resolved.
 func (app *application) Run() {
+	router := app.container.MustResolve("router").(routing.Router)
+
+	if err := router.Validate(); err != nil {
+		panic(err)
+	}
+
+	if encoded, ok := os.LookupEnv(routing.RoutesEnv); ok {
+		app.listRoutes(router, encoded)
+		return
+	}
+
 	conf := app.container.MustResolve("config").(*config.Repository)
 	host, port := conf.Get("host").(string), conf.Get("port").(string)
 
-	http.ListenAndServe(host+":"+port, app.container.MustResolve("router").(routing.Router))
+	http.ListenAndServe(host+":"+port, router)
+}
+
+// listRoutes prints the routes of the router using the options encoded by the gimli routes command.
+func (app *application) listRoutes(router routing.Router, encoded string) {
+	options, err := routing.ParseRouteListOptions(encoded)
+	if err == nil {
+		err = routing.ListRoutes(os.Stdout, router.Collection(), options)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
 
 func (app *application) registerBaseBindings() {
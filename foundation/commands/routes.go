@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/nickbryan/gimli/routing"
+	"github.com/urfave/cli"
+)
+
+type routesCommand struct {
+	dir     string
+	options routing.RouteListOptions
+	output  io.Writer
+}
+
+// Routes lists the routes registered by the gimli application in dir. The application is run with
+// routing.RoutesEnv set so that it prints its routes instead of serving requests.
+func Routes(dir string, options routing.RouteListOptions, output io.Writer) *routesCommand {
+	if output == nil {
+		output = os.Stdout
+	}
+
+	return &routesCommand{dir: dir, options: options, output: output}
+}
+
+// Run the command.
+func (command *routesCommand) Run() error {
+	if err := command.cmd().Run(); err != nil {
+		return cli.NewExitError("Could not list routes: "+err.Error(), 1)
+	}
+
+	return nil
+}
+
+func (command *routesCommand) cmd() *exec.Cmd {
+	cmd := exec.Command("go", "run", ".")
+	cmd.Dir = command.dir
+	cmd.Env = append(os.Environ(), routing.RoutesEnv+"="+command.options.Encode())
+	cmd.Stdout = command.output
+	cmd.Stderr = os.Stderr
+
+	return cmd
+}
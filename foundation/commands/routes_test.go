@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/nickbryan/gimli/routing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutesCommandRunsApplicationWithRoutesEnv(t *testing.T) {
+	output := &bytes.Buffer{}
+	options := routing.RouteListOptions{Format: "json"}
+
+	cmd := Routes("/path/to/app", options, output).cmd()
+
+	assert.Equal(t, []string{"go", "run", "."}, cmd.Args)
+	assert.Equal(t, "/path/to/app", cmd.Dir)
+	assert.Contains(t, cmd.Env, routing.RoutesEnv+"="+options.Encode())
+	assert.Equal(t, output, cmd.Stdout)
+}
+
+func TestRoutesCommandWritesToStdoutByDefault(t *testing.T) {
+	assert.Equal(t, os.Stdout, Routes(".", routing.RouteListOptions{}, nil).output)
+}
@@ -2,6 +2,8 @@ package providers
 
 import (
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"path"
 	"runtime"
 	"testing"
@@ -18,6 +20,101 @@ func TestRoutingProviderBindsRouterInContainer(t *testing.T) {
 	assert.Implements(t, (*routing.Router)(nil), container.MustResolve("router"))
 }
 
+func TestRoutingProviderResolvesControllersFromContainer(t *testing.T) {
+	container := di.NewContainer()
+	container.Instance("controllers.welcome", http.NotFoundHandler())
+
+	(&RoutingProvider{}).Register(container)
+	router := container.MustResolve("router").(routing.Router)
+	router.Get("/", routing.Controller("controllers.welcome@ServeHTTP"))
+
+	assert.NoError(t, router.Validate())
+}
+
+func TestRoutingProviderEnablesDebugFromConfig(t *testing.T) {
+	container := di.NewContainer()
+	container.Instance("config", config.NewPopulatedRepository(map[string]interface{}{"debug": true}))
+
+	(&RoutingProvider{}).Register(container)
+	router := container.MustResolve("router").(routing.Router)
+	router.SetPanicReporter(nil)
+	router.Get("/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		panic("debug me")
+	}))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.Contains(t, response.Body.String(), "Panic: debug me.")
+}
+
+func TestRoutingProviderServesStaticFilesFromPublicPath(t *testing.T) {
+	container := di.NewContainer()
+
+	_, file, _, ok := runtime.Caller(0)
+	if ok == false {
+		assert.Fail(t, "Could not read caller information")
+	}
+	container.Instance("path.public", path.Dir(file)+"/test_assets/public")
+	container.Instance("config", config.NewPopulatedRepository(map[string]interface{}{
+		"static_directories": []interface{}{"css"},
+	}))
+
+	(&RoutingProvider{}).Register(container)
+	router := container.MustResolve("router").(routing.Router)
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/robots.txt", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), "User-agent: *")
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/css/app.css", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), "margin: 0")
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/css/missing.css", nil))
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestRoutingProviderStaticFilesDoNotConflictWithApplicationRoutes(t *testing.T) {
+	container := di.NewContainer()
+
+	_, file, _, ok := runtime.Caller(0)
+	if ok == false {
+		assert.Fail(t, "Could not read caller information")
+	}
+	container.Instance("path.public", path.Dir(file)+"/test_assets/public")
+	container.Instance("config", config.NewPopulatedRepository(map[string]interface{}{
+		"static_directories": []interface{}{"css"},
+	}))
+
+	(&RoutingProvider{}).Register(container)
+	router := container.MustResolve("router").(routing.Router)
+
+	assert.NotPanics(t, func() {
+		router.Get("/robots.txt", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("Generated robots"))
+		}))
+		router.Get("/css/{file}", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("Generated css"))
+		}))
+	})
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/robots.txt", nil))
+	assert.Equal(t, "Generated robots", response.Body.String())
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/css/app.css", nil))
+	assert.Equal(t, "Generated css", response.Body.String())
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/css/nested/app.css", nil))
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
 func TestConfigProviderReadsValuesFromFiles(t *testing.T) {
 	container := di.NewContainer()
 
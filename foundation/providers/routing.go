@@ -1,6 +1,11 @@
 package providers
 
 import (
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/nickbryan/gimli/config"
 	"github.com/nickbryan/gimli/di"
 	"github.com/nickbryan/gimli/routing"
 )
@@ -8,9 +13,75 @@ import (
 // RoutingProvider sets the router in the container.
 type RoutingProvider struct{}
 
-// Register a new router in the container.
+// Register a new router in the container. Files in the public path are served when no route matches the
+// request and controllers referenced with routing.Controller are resolved from the container.
+// Error details are included in error responses when debug is set in the config.
 func (p *RoutingProvider) Register(container di.Container) {
 	container.Bind("router", func(container di.Container) interface{} {
-		return routing.NewRouter()
+		router := routing.NewRouter()
+		router.SetContainer(container)
+
+		if container.Has("config") {
+			debug, _ := container.MustResolve("config").(*config.Repository).GetDefault("debug", false).(bool)
+			router.SetDebug(debug)
+		}
+
+		p.registerStaticFiles(container, router)
+
+		return router
 	})
 }
+
+// registerStaticFiles serves the files at the top level of the public path and every file within the
+// static_directories set in the config. Files are served as a router fallback so that routes registered by
+// the application always take precedence. Precompressed variants are served if static_precompressed is set.
+func (p *RoutingProvider) registerStaticFiles(container di.Container, router routing.Router) {
+	if !container.Has("path.public") {
+		return
+	}
+
+	publicPath := container.MustResolve("path.public").(string)
+
+	directories := []interface{}{}
+	precompressed := false
+
+	if container.Has("config") {
+		conf := container.MustResolve("config").(*config.Repository)
+
+		directories, _ = conf.GetDefault("static_directories", directories).([]interface{})
+		precompressed, _ = conf.GetDefault("static_precompressed", precompressed).(bool)
+	}
+
+	public := routing.NewStaticHandler(publicPath)
+	public.SetPrecompressed(precompressed)
+
+	router.UseFallback(topLevelOnly(public.Fallback("/")))
+
+	for _, directory := range directories {
+		name, ok := directory.(string)
+		if !ok || strings.Trim(name, "/") == "" {
+			continue
+		}
+
+		handler := routing.NewStaticHandler(filepath.Join(publicPath, name))
+		handler.SetPrecompressed(precompressed)
+
+		router.UseFallback(handler.Fallback(name))
+	}
+}
+
+// topLevelOnly restricts the middleware to requests for a path with a single segment, such as /robots.txt.
+func topLevelOnly(middleware routing.Middleware) routing.Middleware {
+	return func(next http.Handler) http.Handler {
+		wrapped := middleware(next)
+
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			if strings.Count(request.URL.Path, "/") != 1 {
+				next.ServeHTTP(response, request)
+				return
+			}
+
+			wrapped.ServeHTTP(response, request)
+		})
+	}
+}
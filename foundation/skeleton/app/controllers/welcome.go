@@ -10,6 +10,8 @@ type WelcomeController struct {
 	Printer app.PrinterService
 }
 
-func (controller *WelcomeController) Welcome(rw http.ResponseWriter, r *http.Request) {
-	rw.Write([]byte(controller.Printer.Render()))
+func (controller *WelcomeController) Welcome(rw http.ResponseWriter, r *http.Request) error {
+	_, err := rw.Write([]byte(controller.Printer.Render()))
+
+	return err
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/nickbryan/gimli/foundation/commands"
+	"github.com/nickbryan/gimli/routing"
 	"github.com/urfave/cli"
 )
 
@@ -31,6 +32,23 @@ func main() {
 				return commands.New(c.Args().First(), nil).Run()
 			},
 		},
+		{
+			Name:  "routes",
+			Usage: "lists the routes registered by the gimli application in the current directory",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "format, f", Value: "table", Usage: "output format: table, json or markdown"},
+				cli.StringFlag{Name: "method, m", Usage: "only list routes that respond to the request method"},
+				cli.StringFlag{Name: "path, p", Usage: "only list routes whose path starts with the prefix"},
+			},
+
+			Action: func(c *cli.Context) error {
+				return commands.Routes(".", routing.RouteListOptions{
+					Format:     c.String("format"),
+					Method:     c.String("method"),
+					PathPrefix: c.String("path"),
+				}, nil).Run()
+			},
+		},
 	}
 
 	app.Run(os.Args)
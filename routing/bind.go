@@ -0,0 +1,151 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// BindRequest fills the fields of the struct that dst points to from the request. Fields are bound from the
+// url params, query string or form values using the param, query and form struct tags:
+//
+//	type PhotoRequest struct {
+//		ID    int64    `param:"photo"`
+//		Page  int      `query:"page"`
+//		Tags  []string `query:"tag"`
+//		Title string   `form:"title"`
+//	}
+//
+// Strings, bools, integers, floats and slices of these are supported. Unexported fields and fields without a
+// value in the request are left unchanged. A 400 *HTTPError listing every value that could not be converted is
+// returned if binding fails. An error is also returned if dst is not a pointer to a struct or a field has an
+// unsupported type.
+func BindRequest(request *http.Request, dst interface{}) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return errors.New("BindRequest requires a pointer to a struct.")
+	}
+
+	if err := request.ParseForm(); err != nil {
+		return BadRequest("The request form could not be parsed.")
+	}
+
+	params := Params(request)
+	query := request.URL.Query()
+	fields := map[string][]string{}
+
+	target = target.Elem()
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		var name string
+		var values []string
+
+		if name = field.Tag.Get("param"); name != "" {
+			if value, ok := params[name]; ok {
+				values = []string{value}
+			}
+		} else if name = field.Tag.Get("query"); name != "" {
+			values = query[name]
+		} else if name = field.Tag.Get("form"); name != "" {
+			values = request.PostForm[name]
+		} else {
+			continue
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setField(target.Field(i), values); err != nil {
+			if _, ok := err.(*bindError); !ok {
+				return err
+			}
+
+			fields[name] = append(fields[name], "The "+name+" field must be "+err.Error()+".")
+		}
+	}
+
+	if len(fields) > 0 {
+		err := BadRequest("The request could not be bound.")
+		err.Fields = fields
+
+		return err
+	}
+
+	return nil
+}
+
+// bindError is returned by setField when a value can not be converted to the type of the field. It describes
+// the expected type, such as "an integer".
+type bindError struct {
+	expected string
+}
+
+// Error describes the expected type.
+func (err *bindError) Error() string {
+	return err.expected
+}
+
+// setField converts the values to the type of the field and sets it. Slices are set to every value and other
+// types to the first value.
+func setField(field reflect.Value, values []string) error {
+	if field.Kind() != reflect.Slice {
+		return setValue(field, values[0])
+	}
+
+	slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := setValue(slice.Index(i), value); err != nil {
+			return err
+		}
+	}
+
+	field.Set(slice)
+
+	return nil
+}
+
+// setValue converts the value to the type of the field and sets it.
+func setValue(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return &bindError{"true or false"}
+		}
+
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return &bindError{"an integer"}
+		}
+
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return &bindError{"a positive integer"}
+		}
+
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return &bindError{"a number"}
+		}
+
+		field.SetFloat(parsed)
+	default:
+		return errors.New("BindRequest does not support fields of type " + field.Type().String() + ".")
+	}
+
+	return nil
+}
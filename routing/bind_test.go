@@ -0,0 +1,112 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type photoRequest struct {
+	ID        int64    `param:"photo"`
+	Page      int      `query:"page"`
+	Tags      []string `query:"tag"`
+	Published bool     `query:"published"`
+	Title     string   `form:"title"`
+	Rating    float64  `form:"rating"`
+	Ignored   string
+}
+
+func bindingRequest(target string, form url.Values, params map[string]string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return withRouteContext(request, NewRoute("/", nil, nil), params)
+}
+
+func TestBindRequestFillsFieldsFromTheRequest(t *testing.T) {
+	request := bindingRequest(
+		"/photos/4?page=2&tag=a&tag=b&published=true&title=ignored",
+		url.Values{"title": {"Sunset"}, "rating": {"4.5"}},
+		map[string]string{"photo": "4"},
+	)
+
+	bound := photoRequest{Page: 1, Ignored: "kept"}
+
+	assert.Nil(t, BindRequest(request, &bound))
+	assert.Equal(t, photoRequest{
+		ID:        4,
+		Page:      2,
+		Tags:      []string{"a", "b"},
+		Published: true,
+		Title:     "Sunset",
+		Rating:    4.5,
+		Ignored:   "kept",
+	}, bound)
+}
+
+func TestBindRequestLeavesMissingValuesUnchanged(t *testing.T) {
+	bound := photoRequest{Page: 1}
+
+	assert.Nil(t, BindRequest(bindingRequest("/", url.Values{}, nil), &bound))
+	assert.Equal(t, photoRequest{Page: 1}, bound)
+}
+
+func TestBindRequestReturnsABadRequestForInvalidValues(t *testing.T) {
+	request := bindingRequest(
+		"/?page=two&published=maybe",
+		url.Values{"rating": {"high"}},
+		map[string]string{"photo": "latest"},
+	)
+
+	err := BindRequest(request, &photoRequest{})
+
+	assert.Equal(t, &HTTPError{
+		Status:  http.StatusBadRequest,
+		Message: "The request could not be bound.",
+		Fields: map[string][]string{
+			"photo":     {"The photo field must be an integer."},
+			"page":      {"The page field must be an integer."},
+			"published": {"The published field must be true or false."},
+			"rating":    {"The rating field must be a number."},
+		},
+	}, err)
+}
+
+func TestBindRequestRequiresAPointerToAStruct(t *testing.T) {
+	request := bindingRequest("/", url.Values{}, nil)
+
+	assert.EqualError(t, BindRequest(request, photoRequest{}), "BindRequest requires a pointer to a struct.")
+
+	var unsupported struct {
+		Values map[string]string `query:"values"`
+	}
+
+	request = bindingRequest("/?values=a", url.Values{}, nil)
+	assert.EqualError(t, BindRequest(request, &unsupported), "BindRequest does not support fields of type map[string]string.")
+}
+
+func TestBindRequestErrorsAreRenderedFromHandlerFuncs(t *testing.T) {
+	router := NewRouter()
+	router.Get("/photos/:photo", HandlerFunc(func(rw http.ResponseWriter, r *http.Request) error {
+		var bound photoRequest
+
+		return BindRequest(r, &bound)
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/photos/latest", nil)
+	request.Header.Set("Accept", "application/json")
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, request)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.JSONEq(
+		t,
+		`{"status": 400, "message": "The request could not be bound.", "errors": {"photo": ["The photo field must be an integer."]}}`,
+		response.Body.String(),
+	)
+}
@@ -0,0 +1,131 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+)
+
+// ErrModelNotFound can be returned by a ModelResolver when there is no model for the param value. The router
+// responds with its not found handler, as it does when a resolver returns a nil model.
+var ErrModelNotFound = errors.New("Model not found.")
+
+// ModelResolver loads the model for the value of a url param.
+type ModelResolver func(value string, request *http.Request) (interface{}, error)
+
+// ModelBinder can be implemented by services in the container that load models, see Router.BindService.
+type ModelBinder interface {
+	ResolveModel(value string, request *http.Request) (interface{}, error)
+}
+
+// Bind registers a resolver that loads the model for the named url param. When a matched route has the param
+// the model is loaded after the group and route middleware, directly before the handler is called, so that
+// middleware such as authorisation can reject a request before any model is loaded. The model can be retrieved
+// with Model.
+// Bindings apply to every route of the router, including routes registered in groups.
+func (r *router) Bind(param string, resolver ModelResolver) {
+	if r.root.binders == nil {
+		r.root.binders = map[string]ModelResolver{}
+	}
+
+	r.root.binders[param] = resolver
+}
+
+// BindService works like Bind but the resolver is the service with the id in the router's container. The
+// service must be a ModelResolver, a function with the same signature or implement ModelBinder. It is
+// resolved when a model is first loaded and checked by Validate.
+func (r *router) BindService(param, id string) {
+	root := r.root
+
+	if root.boundServices == nil {
+		root.boundServices = map[string]string{}
+	}
+
+	root.boundServices[param] = id
+
+	r.Bind(param, func(value string, request *http.Request) (interface{}, error) {
+		resolver, err := root.modelResolverService(id)
+		if err != nil {
+			return nil, err
+		}
+
+		return resolver(value, request)
+	})
+}
+
+// modelResolverService resolves the service with the id from the container as a ModelResolver.
+func (r *router) modelResolverService(id string) (ModelResolver, error) {
+	if r.container == nil {
+		return nil, errors.New("Model binder " + id + " can not be resolved as the router has no container.")
+	}
+
+	service, err := r.container.Resolve(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resolver := service.(type) {
+	case ModelResolver:
+		return resolver, nil
+	case func(string, *http.Request) (interface{}, error):
+		return resolver, nil
+	case ModelBinder:
+		return resolver.ResolveModel, nil
+	default:
+		return nil, errors.New("Model binder " + id + " is not a ModelResolver or ModelBinder.")
+	}
+}
+
+// bindModels is the innermost middleware of a matched route, it loads the models for the bound params of the
+// request before the handler is called. The not found handler is called if a model does not exist and any
+// other error is passed to the error handler.
+func (r *router) bindModels(handler http.Handler) http.Handler {
+	if len(r.binders) == 0 {
+		return handler
+	}
+
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		params := Params(request)
+		models := map[string]interface{}{}
+
+		// Params are bound in order of their names so that resolvers are always called in the same order.
+		names := []string{}
+		for name := range params {
+			if _, ok := r.binders[name]; ok {
+				names = append(names, name)
+			}
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			model, err := r.binders[name](params[name], request)
+			if errors.Is(err, ErrModelNotFound) || (err == nil && model == nil) {
+				r.notFoundHandler.ServeHTTP(response, request)
+				return
+			}
+
+			if err != nil {
+				r.handleError(response, request, err)
+				return
+			}
+
+			models[name] = model
+		}
+
+		if len(models) > 0 {
+			request = request.WithContext(context.WithValue(request.Context(), modelsContextKey, models))
+		}
+
+		handler.ServeHTTP(response, request)
+	})
+}
+
+// Model returns the model loaded for the named url param by a resolver registered with Router.Bind, or nil
+// if no model was loaded.
+func Model(request *http.Request, name string) interface{} {
+	models, _ := request.Context().Value(modelsContextKey).(map[string]interface{})
+
+	return models[name]
+}
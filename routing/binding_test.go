@@ -0,0 +1,171 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nickbryan/gimli/di"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	name string
+}
+
+type userRepository struct {
+	users map[string]*user
+}
+
+func (repository *userRepository) ResolveModel(value string, request *http.Request) (interface{}, error) {
+	if found, ok := repository.users[value]; ok {
+		return found, nil
+	}
+
+	return nil, ErrModelNotFound
+}
+
+func userRouter() Router {
+	router := NewRouter()
+	router.Get("/users/:user", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("Hello " + Model(r, "user").(*user).name + "."))
+	}))
+
+	return router
+}
+
+func TestBoundModelsAreLoadedBeforeTheHandlerRuns(t *testing.T) {
+	calls := 0
+
+	router := userRouter()
+	router.Bind("user", func(value string, r *http.Request) (interface{}, error) {
+		calls++
+		return &user{name: value}, nil
+	})
+
+	assert.Equal(t, "Hello bob.", runRequest(http.MethodGet, "/users/bob", router))
+	assert.Equal(t, 1, calls)
+}
+
+func TestMiddlewareCanRejectRequestsBeforeModelsAreLoaded(t *testing.T) {
+	calls := []string{}
+
+	forbid := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				rw.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			calls = append(calls, "authorized")
+			next.ServeHTTP(rw, r)
+		})
+	}
+
+	router := NewRouter()
+	router.Bind("user", func(value string, r *http.Request) (interface{}, error) {
+		calls = append(calls, "resolved "+value)
+		return nil, ErrModelNotFound
+	})
+	router.Group("/admin", func(group Router) {
+		group.Get("/users/:user", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {})).Use(forbid)
+	})
+
+	for _, id := range []string{"bob", "missing"} {
+		response := httptest.NewRecorder()
+		router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/admin/users/"+id, nil))
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	}
+
+	assert.Empty(t, calls)
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/admin/users/missing", nil)
+	request.Header.Set("Authorization", "token")
+	router.Dispatch(response, request)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, []string{"authorized", "resolved missing"}, calls)
+}
+
+func TestNotFoundIsReturnedWhenTheModelDoesNotExist(t *testing.T) {
+	router := userRouter()
+	router.SetNotFoundHandler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		rw.Write([]byte("No such user."))
+	}))
+
+	router.Bind("user", func(value string, r *http.Request) (interface{}, error) {
+		return nil, ErrModelNotFound
+	})
+	assert.Equal(t, "No such user.", runRequest(http.MethodGet, "/users/bob", router))
+
+	router.Bind("user", func(value string, r *http.Request) (interface{}, error) {
+		return nil, nil
+	})
+	assert.Equal(t, "No such user.", runRequest(http.MethodGet, "/users/bob", router))
+}
+
+func TestModelResolverErrorsArePassedToTheErrorHandler(t *testing.T) {
+	router := userRouter()
+	router.Bind("user", func(value string, r *http.Request) (interface{}, error) {
+		return nil, errors.New("database unavailable")
+	})
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/users/bob", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestModelIsNilWhenNothingIsBound(t *testing.T) {
+	var model interface{} = "unset"
+
+	router := NewRouter()
+	router.Bind("user", func(value string, r *http.Request) (interface{}, error) {
+		return &user{}, nil
+	})
+	router.Get("/posts/:post", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		model = Model(r, "post")
+	}))
+
+	runRequest(http.MethodGet, "/posts/1", router)
+
+	assert.Nil(t, model)
+}
+
+func TestModelsCanBeBoundThroughTheContainer(t *testing.T) {
+	container := di.NewContainer()
+	container.Instance("repositories.users", &userRepository{users: map[string]*user{"1": {name: "alice"}}})
+
+	router := userRouter()
+	router.SetContainer(container)
+	router.BindService("user", "repositories.users")
+
+	assert.Nil(t, router.Validate())
+	assert.Equal(t, "Hello alice.", runRequest(http.MethodGet, "/users/1", router))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/users/2", nil))
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestValidateReportsInvalidModelBinders(t *testing.T) {
+	container := di.NewContainer()
+	container.Instance("not.a.binder", "text")
+
+	router := NewRouter()
+	router.SetContainer(container)
+	router.BindService("user", "repositories.users")
+	router.BindService("post", "not.a.binder")
+
+	assert.EqualError(
+		t,
+		router.Validate(),
+		"Binding for param post is invalid: Model binder not.a.binder is not a ModelResolver or ModelBinder.\n"+
+			"Binding for param user is invalid: Abstract repositories.users does not exist in container.",
+	)
+}
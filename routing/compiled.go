@@ -0,0 +1,180 @@
+package routing
+
+import (
+	"strings"
+	"sync"
+)
+
+// compiledNode is a read only copy of a trie node built by RouteCollection.Compile. Static children are
+// indexed by their text so they can be found without scanning, and params are collected in pooled storage
+// so that looking up a path does not allocate.
+type compiledNode struct {
+	// Static children keyed by their text and by their lower case text for case insensitive lookups.
+	static map[string]*compiledNode
+	folded map[string][]*compiledNode
+
+	// Named param children in order of precedence followed by wildcard children.
+	named     []*compiledNode
+	wildcards []*compiledNode
+
+	segment pathSegment
+	routes  []*Route
+}
+
+// urlParam is a named param captured while looking up a path in a compiled node.
+type urlParam struct {
+	name  string
+	value string
+}
+
+// urlParams holds the params captured by a lookup. They are taken from and returned to paramsPool.
+type urlParams struct {
+	list []urlParam
+}
+
+var paramsPool = sync.Pool{
+	New: func() interface{} {
+		return &urlParams{list: make([]urlParam, 0, 8)}
+	},
+}
+
+// compileTrie copies the trie node and its children into a compiledNode.
+func compileTrie(t *trie) *compiledNode {
+	node := &compiledNode{
+		static:  map[string]*compiledNode{},
+		folded:  map[string][]*compiledNode{},
+		segment: t.segment,
+		routes:  append([]*Route(nil), t.routes...),
+	}
+
+	// Children of the trie are already ordered by precedence.
+	for _, child := range t.children {
+		compiled := compileTrie(child)
+
+		switch {
+		case child.segment.isWildcard:
+			node.wildcards = append(node.wildcards, compiled)
+		case child.segment.isNamedParam:
+			node.named = append(node.named, compiled)
+		default:
+			node.static[child.segment.value] = compiled
+
+			folded := strings.ToLower(child.segment.value)
+			node.folded[folded] = append(node.folded[folded], compiled)
+		}
+	}
+
+	return node
+}
+
+// lookup finds the routes for the path in the same way as trie.searchWhere, appending any captured params to
+// params. Nil is returned if no accepted routes are found.
+func (node *compiledNode) lookup(path string, fold bool, accept func(routes []*Route) bool, params *urlParams) []*Route {
+	i := strings.IndexByte(path, '/')
+	if i == -1 {
+		return nil
+	}
+
+	if found := node.match(path[i+1:], false, fold, accept, params); found != nil {
+		return found.routes
+	}
+
+	return nil
+}
+
+// match searches the children of the node for the remaining path. Done is set once every segment of the
+// path has been matched. Params are added before trying each child and removed again if the child does not
+// lead to an accepted route so that only the params of the successful branch are returned.
+func (node *compiledNode) match(rest string, done, fold bool, accept func(routes []*Route) bool, params *urlParams) *compiledNode {
+	if done {
+		if !node.accepts(accept) {
+			return nil
+		}
+
+		return node
+	}
+
+	segment, remaining, last := rest, "", true
+	if i := strings.IndexByte(rest, '/'); i != -1 {
+		segment, remaining, last = rest[:i], rest[i+1:], false
+	}
+
+	static := node.static[segment]
+	if static != nil {
+		if found := static.match(remaining, last, fold, accept, params); found != nil {
+			return found
+		}
+	}
+
+	if fold {
+		for _, child := range node.folded[strings.ToLower(segment)] {
+			if child == static {
+				continue
+			}
+
+			if found := child.match(remaining, last, fold, accept, params); found != nil {
+				return found
+			}
+		}
+	}
+
+	for _, child := range node.named {
+		if !child.segment.matches(segment) {
+			continue
+		}
+
+		mark := len(params.list)
+		params.list = append(params.list, urlParam{child.segment.value, segment})
+
+		if found := child.match(remaining, last, fold, accept, params); found != nil {
+			return found
+		}
+
+		params.list = params.list[:mark]
+	}
+
+	for _, child := range node.wildcards {
+		if !child.accepts(accept) {
+			continue
+		}
+
+		params.list = append(params.list, urlParam{child.segment.value, rest})
+
+		return child
+	}
+
+	return nil
+}
+
+// accepts checks that the node has routes and that they are accepted, every node with routes is accepted
+// when accept is nil.
+func (node *compiledNode) accepts(accept func(routes []*Route) bool) bool {
+	return len(node.routes) > 0 && (accept == nil || accept(node.routes))
+}
+
+// search looks up the path and returns the routes with the captured params. The params map is nil when
+// no params were captured.
+func (node *compiledNode) search(path string, fold bool) ([]*Route, map[string]string) {
+	return node.searchWhere(path, fold, nil)
+}
+
+// searchWhere works like search but skips nodes whose routes are not accepted.
+func (node *compiledNode) searchWhere(path string, fold bool, accept func(routes []*Route) bool) ([]*Route, map[string]string) {
+	params := paramsPool.Get().(*urlParams)
+	defer func() {
+		params.list = params.list[:0]
+		paramsPool.Put(params)
+	}()
+
+	routes := node.lookup(path, fold, accept, params)
+	if routes == nil || len(params.list) == 0 {
+		return routes, nil
+	}
+
+	values := make(map[string]string, len(params.list))
+	for _, param := range params.list {
+		values[param.name] = param.value
+	}
+
+	return routes, values
+}
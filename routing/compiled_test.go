@@ -0,0 +1,192 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectionForCompiling() *RouteCollection {
+	collection := NewRouteCollection()
+
+	for _, path := range []string{
+		"/",
+		"/users",
+		"/users/me",
+		"/users/me/settings",
+		"/users/:id",
+		"/users/{id:[0-9]+}/posts",
+		"/users/:id/posts/:post",
+		"/Files/*filepath",
+		"/:section/edit",
+		"/about",
+		"/*path",
+	} {
+		collection.Add(NewRoute(path, nil, nil))
+	}
+
+	return collection
+}
+
+func TestCompiledLookupsMatchTheTrie(t *testing.T) {
+	collection := collectionForCompiling()
+	compiled := compileTrie(collection.routes.(*trie))
+
+	for _, path := range []string{
+		"/",
+		"/users",
+		"/users/",
+		"/users/me",
+		"/users/me/settings",
+		"/users/me/posts",
+		"/users/1",
+		"/users/1/posts",
+		"/users/abc/posts",
+		"/users/1/posts/2",
+		"/Files/css/app.css",
+		"/files/css/app.css",
+		"/about",
+		"/about/edit",
+		"/contact",
+		"/contact/us",
+	} {
+		for _, fold := range []bool{false, true} {
+			var routes []*Route
+			var params map[string]string
+
+			if fold {
+				routes, params = collection.routes.searchFold(path)
+			} else {
+				routes, params = collection.routes.search(path)
+			}
+
+			compiledRoutes, compiledParams := compiled.search(path, fold)
+			if compiledParams == nil {
+				compiledParams = map[string]string{}
+			}
+
+			assert.Equal(t, routes, compiledRoutes, path)
+			assert.Equal(t, params, compiledParams, path)
+		}
+	}
+}
+
+func TestCompiledStaticLookupsDoNotAllocate(t *testing.T) {
+	collection := collectionForCompiling()
+	collection.Compile()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		collection.search("/users/me/settings", false)
+	})
+
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestCompiledTableIsRebuiltWhenRoutesChange(t *testing.T) {
+	collection := NewRouteCollection()
+	route := NewRoute("/users", nil, nil)
+	collection.Add(route)
+	collection.Compile()
+
+	assert.True(t, collection.Compiled())
+
+	posts := NewRoute("/posts", nil, nil)
+	assert.Nil(t, collection.Add(posts))
+	assert.Equal(t, []*Route{posts}, collection.RoutesByPath("/posts").Routes)
+
+	route.SetPath("/people")
+	assert.Empty(t, collection.RoutesByPath("/users").Routes)
+	assert.Equal(t, []*Route{route}, collection.RoutesByPath("/people").Routes)
+
+	assert.True(t, collection.Remove(posts))
+	assert.Empty(t, collection.RoutesByPath("/posts").Routes)
+}
+
+func TestCompiledRouterDispatchesRequests(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The user is " + Param(r, "id") + "."))
+	}))
+	router.Compile()
+
+	assert.Contains(t, runRequest(http.MethodGet, "/users/4", router), "The user is 4.")
+}
+
+func benchmarkCollection() *RouteCollection {
+	collection := NewRouteCollection()
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {})
+
+	for _, resource := range []string{"users", "posts", "comments", "photos", "videos", "tags", "categories"} {
+		collection.Add(NewRoute("/api/"+resource, nil, handler))
+		collection.Add(NewRoute("/api/"+resource+"/:id", nil, handler))
+		collection.Add(NewRoute("/api/"+resource+"/:id/edit", nil, handler))
+		collection.Add(NewRoute("/api/"+resource+"/{id:[0-9]+}/history", nil, handler))
+	}
+
+	collection.Add(NewRoute("/assets/*filepath", nil, handler))
+
+	return collection
+}
+
+func BenchmarkTrieStaticLookup(b *testing.B) {
+	collection := benchmarkCollection()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		collection.search("/api/categories", false)
+	}
+}
+
+func BenchmarkCompiledStaticLookup(b *testing.B) {
+	collection := benchmarkCollection()
+	collection.Compile()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		collection.search("/api/categories", false)
+	}
+}
+
+func BenchmarkTrieParamLookup(b *testing.B) {
+	collection := benchmarkCollection()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		collection.search("/api/categories/42/history", false)
+	}
+}
+
+func BenchmarkCompiledParamLookup(b *testing.B) {
+	collection := benchmarkCollection()
+	collection.Compile()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		collection.search("/api/categories/42/history", false)
+	}
+}
+
+func BenchmarkCompiledDispatch(b *testing.B) {
+	router := NewRouterFromCollection(benchmarkCollection())
+	router.Compile()
+
+	request := httptest.NewRequest(http.MethodGet, "/api/categories", nil)
+	response := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		router.Dispatch(response, request)
+	}
+}
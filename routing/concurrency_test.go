@@ -0,0 +1,122 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests are intended to be run with the race detector enabled.
+
+func testRoutesCanBeChangedWhileDispatching(t *testing.T, compile bool) {
+	router := NewRouter()
+	router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("user " + Param(r, "id")))
+	}))
+
+	if compile {
+		router.Compile()
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 200; j++ {
+				response := httptest.NewRecorder()
+				router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/users/"+strconv.Itoa(j), nil))
+
+				assert.Equal(t, "user "+strconv.Itoa(j), response.Body.String())
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for j := 0; j < 50; j++ {
+			route := NewRoute("/plugins/"+strconv.Itoa(j), nil, http.NotFoundHandler()).Named("plugin." + strconv.Itoa(j))
+
+			assert.Nil(t, router.Add(route))
+			router.Dispatch(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/plugins/"+strconv.Itoa(j), nil))
+			router.URL("plugin."+strconv.Itoa(j), nil, nil)
+
+			if j%2 == 0 {
+				assert.True(t, router.Remove(route))
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	assert.Equal(t, 26, router.Collection().Count())
+}
+
+func TestRoutesCanBeAddedAndRemovedWhileDispatching(t *testing.T) {
+	testRoutesCanBeChangedWhileDispatching(t, false)
+}
+
+func TestCompiledRoutesCanBeAddedAndRemovedWhileDispatching(t *testing.T) {
+	testRoutesCanBeChangedWhileDispatching(t, true)
+}
+
+func TestChainedRouteConfigurationIsSafeWhileDispatching(t *testing.T) {
+	router := NewRouter()
+	router.Compile()
+
+	authorize := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Header().Set("X-Authorized", "true")
+			next.ServeHTTP(rw, r)
+		})
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 200; j++ {
+				response := httptest.NewRecorder()
+				router.Dispatch(response, httptest.NewRequest(http.MethodGet, "http://example.com/pages/"+strconv.Itoa(j%50)+"/1", nil))
+				router.URL("pages."+strconv.Itoa(j%50), map[string]string{"page": "1"}, nil)
+
+				assert.Contains(t, []int{http.StatusOK, http.StatusNotFound}, response.Code)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for j := 0; j < 50; j++ {
+			router.Get("/pages/"+strconv.Itoa(j)+"/:page", http.NotFoundHandler()).
+				Host("example.com").
+				Scheme("http").
+				Use(authorize).
+				Where("page", "[0-9]+").
+				Named("pages." + strconv.Itoa(j)).
+				DisableAutoOptions().
+				SetHandler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+		}
+	}()
+
+	wg.Wait()
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "http://example.com/pages/49/1", nil))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "true", response.Header().Get("X-Authorized"))
+}
@@ -0,0 +1,54 @@
+package routing
+
+import (
+	"context"
+	"net/http"
+)
+
+// contextKey is used to store routing information on the request context without colliding with
+// keys set by other packages.
+type contextKey int
+
+const (
+	paramsContextKey contextKey = iota
+	routeContextKey
+	routerContextKey
+	modelsContextKey
+)
+
+// withRouteContext returns a shallow copy of the request with the matched route and the parsed url params
+// attached to its context. Params captured by the route's matchers, such as host params, are merged in with
+// the url params taking priority.
+func withRouteContext(request *http.Request, route *Route, params map[string]string) *http.Request {
+	merged := route.matcherParams(request)
+	for name, value := range params {
+		merged[name] = value
+	}
+
+	ctx := context.WithValue(request.Context(), paramsContextKey, merged)
+	ctx = context.WithValue(ctx, routeContextKey, route)
+
+	return request.WithContext(ctx)
+}
+
+// Params will return all named params parsed from the url of the matched route. An empty map is returned
+// if the request was not dispatched through a Router.
+func Params(request *http.Request) map[string]string {
+	if params, ok := request.Context().Value(paramsContextKey).(map[string]string); ok {
+		return params
+	}
+
+	return map[string]string{}
+}
+
+// Param will return the value of the named param parsed from the url or an empty string if it was not found.
+func Param(request *http.Request, name string) string {
+	return Params(request)[name]
+}
+
+// CurrentRoute will return the Route that was matched for the request or nil if no route was matched.
+func CurrentRoute(request *http.Request) *Route {
+	route, _ := request.Context().Value(routeContextKey).(*Route)
+
+	return route
+}
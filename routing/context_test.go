@@ -0,0 +1,48 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamsAreAddedToRequestContextWhenRouteIsMatched(t *testing.T) {
+	var params map[string]string
+	var id string
+
+	router := NewRouter()
+	router.Get("/users/:id/posts/:post", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		params = Params(r)
+		id = Param(r, "id")
+	}))
+
+	runRequest(http.MethodGet, "/users/4/posts/9", router)
+
+	assert.Equal(t, map[string]string{"id": "4", "post": "9"}, params)
+	assert.Equal(t, "4", id)
+}
+
+func TestCurrentRouteIsAddedToRequestContextWhenRouteIsMatched(t *testing.T) {
+	var current *Route
+
+	route := NewRoute("/test", nil, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		current = CurrentRoute(r)
+	}))
+
+	routeCollection := NewRouteCollection()
+	routeCollection.Add(route)
+
+	runRequest(http.MethodGet, "/test", NewRouterFromCollection(routeCollection))
+
+	assert.Equal(t, route, current)
+}
+
+func TestContextAccessorsReturnZeroValuesWhenRequestWasNotDispatched(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	assert.Empty(t, Params(request))
+	assert.Equal(t, "", Param(request, "id"))
+	assert.Nil(t, CurrentRoute(request))
+}
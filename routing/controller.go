@@ -0,0 +1,130 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nickbryan/gimli/di"
+)
+
+// ControllerAction is a handler that calls a method on a controller that is resolved from the router's
+// container. The controller is resolved when the first request is handled and reused for later requests.
+type ControllerAction struct {
+	id     string
+	method string
+
+	// Set when the action is registered through a router so that the router's container can be used.
+	router *router
+
+	once    sync.Once
+	handler http.Handler
+	err     error
+}
+
+// Controller creates a handler from a reference in the form id@Method, where id is the container binding of
+// the controller and Method is the name of a method with the signature of an http.HandlerFunc or a HandlerFunc.
+// For example router.Get("/", routing.Controller("controllers.welcome@Welcome")). Controller panics if the
+// reference is not in this form.
+func Controller(reference string) *ControllerAction {
+	parts := strings.Split(reference, "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		panic("Controller reference " + reference + " must be in the form id@Method.")
+	}
+
+	return &ControllerAction{id: parts[0], method: parts[1]}
+}
+
+// String returns the reference the action was created from.
+func (action *ControllerAction) String() string {
+	return action.id + "@" + action.method
+}
+
+// ServeHTTP resolves the controller on the first request and calls the referenced method. ServeHTTP panics if
+// the controller can not be resolved, use Router.Validate to find these errors when the application boots.
+func (action *ControllerAction) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	action.once.Do(func() {
+		action.handler, action.err = action.resolve()
+	})
+
+	if action.err != nil {
+		panic(action.err)
+	}
+
+	action.handler.ServeHTTP(response, request)
+}
+
+// resolve resolves the controller from the container and looks up the referenced method.
+func (action *ControllerAction) resolve() (http.Handler, error) {
+	if action.router == nil || action.router.container == nil {
+		return nil, errors.New("Controller " + action.String() + " can not be resolved as the router has no container.")
+	}
+
+	controller, err := action.router.container.Resolve(action.id)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, ok := controllerMethod(controller, action.method)
+	if !ok {
+		return nil, errors.New("Controller " + action.id + " does not have a handler method " + action.method + ".")
+	}
+
+	return handler, nil
+}
+
+// SetContainer sets the container that controllers referenced with Controller are resolved from.
+func (r *router) SetContainer(container di.Container) {
+	r.root.container = container
+}
+
+// Validate checks that no route conflicts with another route, see RouteCollection.Conflicts, that the controller
+// of every route registered with Controller can be resolved and has the referenced method and that the model
+// binders registered with BindService can be resolved. Routes added to the collection directly will use the
+// router's container. Controllers and model binders are resolved from the container while validating. A
+// *ValidationError describing every failure is returned.
+func (r *router) Validate() error {
+	failures := []error{}
+
+	for _, conflict := range r.collection.Conflicts() {
+		failures = append(failures, conflict)
+	}
+
+	r.collection.Each(func(route *Route) bool {
+		action, ok := route.Handler().(*ControllerAction)
+		if !ok {
+			return true
+		}
+
+		if action.router == nil {
+			action.router = r.root
+		}
+
+		if _, err := action.resolve(); err != nil {
+			failures = append(failures, &ControllerError{Route: route, Action: action, Err: err})
+		}
+
+		return true
+	})
+
+	params := []string{}
+	for param := range r.root.boundServices {
+		params = append(params, param)
+	}
+
+	sort.Strings(params)
+
+	for _, param := range params {
+		if _, err := r.root.modelResolverService(r.root.boundServices[param]); err != nil {
+			failures = append(failures, errors.New("Binding for param "+param+" is invalid: "+err.Error()))
+		}
+	}
+
+	if len(failures) > 0 {
+		return &ValidationError{Errors: failures}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/nickbryan/gimli/di"
+	"github.com/stretchr/testify/assert"
+)
+
+type welcomeController struct {
+	message string
+}
+
+func (controller *welcomeController) Welcome(rw http.ResponseWriter, r *http.Request) {
+	rw.Write([]byte(controller.message))
+}
+
+func (controller *welcomeController) NotAHandler() {}
+
+func (controller *welcomeController) Missing(rw http.ResponseWriter, r *http.Request) error {
+	return NotFound("")
+}
+
+func TestControllerPanicsWhenReferenceIsMalformed(t *testing.T) {
+	for _, reference := range []string{"controllers.welcome", "controllers.welcome@", "@Welcome", "a@b@c"} {
+		assert.Panics(t, func() { Controller(reference) }, reference)
+	}
+}
+
+func TestControllerIsResolvedFromTheContainerOnFirstRequest(t *testing.T) {
+	resolved := 0
+
+	container := di.NewContainer()
+	container.Factory("controllers.welcome", func(container di.Container) interface{} {
+		resolved++
+		return &welcomeController{message: "Welcome!"}
+	})
+
+	router := NewRouter()
+	router.SetContainer(container)
+	router.Get("/", Controller("controllers.welcome@Welcome"))
+
+	assert.Equal(t, 0, resolved)
+
+	assert.Contains(t, runRequest(http.MethodGet, "/", router), "Welcome!")
+	assert.Contains(t, runRequest(http.MethodGet, "/", router), "Welcome!")
+	assert.Equal(t, 1, resolved)
+}
+
+func TestControllerMethodsCanReturnErrors(t *testing.T) {
+	container := di.NewContainer()
+	container.Instance("controllers.welcome", &welcomeController{})
+
+	router := NewRouter()
+	router.SetContainer(container)
+	router.Get("/", Controller("controllers.welcome@Missing"))
+
+	assert.Equal(t, "Not Found\n", runRequest(http.MethodGet, "/", router))
+}
+
+func TestControllerErrorsArePassedToTheErrorHandler(t *testing.T) {
+	var handled error
+
+	router := NewRouter()
+	router.SetContainer(di.NewContainer())
+	router.SetPanicReporter(nil)
+	router.SetErrorHandler(func(rw http.ResponseWriter, r *http.Request, err error) {
+		handled = err
+	})
+	router.Get("/", Controller("controllers.missing@Welcome"))
+
+	runRequest(http.MethodGet, "/", router)
+
+	assert.EqualError(t, errors.Unwrap(handled), "Abstract controllers.missing does not exist in container.")
+}
+
+func TestValidateReportsUnresolvableControllers(t *testing.T) {
+	container := di.NewContainer()
+	container.Bind("controllers.welcome", func(container di.Container) interface{} {
+		return &welcomeController{}
+	})
+
+	router := NewRouter()
+	router.SetContainer(container)
+	router.Get("/", Controller("controllers.welcome@Welcome"))
+
+	assert.NoError(t, router.Validate())
+
+	router.Get("/missing", Controller("controllers.missing@Welcome"))
+	router.Get("/method", Controller("controllers.welcome@Goodbye"))
+	router.Get("/signature", Controller("controllers.welcome@NotAHandler"))
+
+	err := router.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Len(t, err.(*ValidationError).Errors, 3)
+	assert.Equal(
+		t,
+		"Route GET /missing uses controllers.missing@Welcome: Abstract controllers.missing does not exist in container.\n"+
+			"Route GET /method uses controllers.welcome@Goodbye: Controller controllers.welcome does not have a handler method Goodbye.\n"+
+			"Route GET /signature uses controllers.welcome@NotAHandler: Controller controllers.welcome does not have a handler method NotAHandler.",
+		err.Error(),
+	)
+}
+
+func TestValidateReportsAMissingContainer(t *testing.T) {
+	router := NewRouter()
+	router.Get("/", Controller("controllers.welcome@Welcome"))
+
+	assert.EqualError(
+		t,
+		router.Validate(),
+		"Route GET / uses controllers.welcome@Welcome: Controller controllers.welcome@Welcome can not be resolved as the router has no container.",
+	)
+}
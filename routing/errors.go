@@ -0,0 +1,133 @@
+package routing
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DuplicateNameError is returned when a route is added to a collection, or renamed, using a name that
+// already belongs to another route in the collection.
+type DuplicateNameError struct {
+	Name     string
+	Route    *Route
+	Existing *Route
+}
+
+// Error describes the name conflict.
+func (err *DuplicateNameError) Error() string {
+	return "Route name " + err.Name + " is already used by route " + err.Existing.Path() + "."
+}
+
+// DuplicateRouteError is reported by RouteCollection.Conflicts when a collection contains a route for the same
+// path and at least one of the same methods as an existing route, which makes the route unreachable.
+type DuplicateRouteError struct {
+	Route    *Route
+	Existing *Route
+}
+
+// Error describes the route conflict.
+func (err *DuplicateRouteError) Error() string {
+	return "Route " + strings.Join(err.Route.Methods(), "|") + " " + err.Route.Path() +
+		" overlaps with existing route " + strings.Join(err.Existing.Methods(), "|") + " " + err.Existing.Path() + "."
+}
+
+// ControllerError is returned when the controller of a route registered with Controller can not be resolved or
+// does not have the referenced method.
+type ControllerError struct {
+	Route  *Route
+	Action *ControllerAction
+	Err    error
+}
+
+// Error describes the route and the reason the controller could not be resolved.
+func (err *ControllerError) Error() string {
+	return "Route " + strings.Join(err.Route.Methods(), "|") + " " + err.Route.Path() + " uses " +
+		err.Action.String() + ": " + err.Err.Error()
+}
+
+// ValidationError is returned by Router.Validate and contains an error for every route that failed validation.
+type ValidationError struct {
+	Errors []error
+}
+
+// Error lists the validation failures, one per line.
+func (err *ValidationError) Error() string {
+	messages := []string{}
+	for _, failure := range err.Errors {
+		messages = append(messages, failure.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// PanicError is passed to the error handler when the router recovers from a panic while serving a request.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+// Error describes the value that was passed to panic.
+func (err *PanicError) Error() string {
+	return "Panic: " + fmt.Sprint(err.Value) + "."
+}
+
+// Unwrap returns the value that was passed to panic if it is an error.
+func (err *PanicError) Unwrap() error {
+	wrapped, _ := err.Value.(error)
+
+	return wrapped
+}
+
+// HTTPError is an error that is rendered with the status code and message that it carries. Validation errors
+// also carry a list of messages for each invalid field.
+type HTTPError struct {
+	Status  int
+	Message string
+	Fields  map[string][]string
+
+	// The error that caused the HTTPError, if any. It is not included in the response.
+	Err error
+}
+
+// NewHTTPError creates an HTTPError with the status code. The status text is used if the message is empty.
+func NewHTTPError(status int, message string) *HTTPError {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	return &HTTPError{Status: status, Message: message}
+}
+
+// BadRequest creates an HTTPError with the status code 400.
+func BadRequest(message string) *HTTPError {
+	return NewHTTPError(http.StatusBadRequest, message)
+}
+
+// Forbidden creates an HTTPError with the status code 403.
+func Forbidden(message string) *HTTPError {
+	return NewHTTPError(http.StatusForbidden, message)
+}
+
+// NotFound creates an HTTPError with the status code 404.
+func NotFound(message string) *HTTPError {
+	return NewHTTPError(http.StatusNotFound, message)
+}
+
+// Validation creates an HTTPError with the status code 422 and the messages for each invalid field.
+func Validation(fields map[string][]string) *HTTPError {
+	err := NewHTTPError(http.StatusUnprocessableEntity, "The given data was invalid.")
+	err.Fields = fields
+
+	return err
+}
+
+// Error returns the message of the error.
+func (err *HTTPError) Error() string {
+	return err.Message
+}
+
+// Unwrap returns the error that caused the HTTPError.
+func (err *HTTPError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,98 @@
+package routing
+
+import (
+	"net/http"
+	"strings"
+)
+
+// routeGroup holds the attributes that are shared by every route registered through a grouped Router. Groups
+// can be nested and each route keeps a reference to the group it was registered in so that matchers and
+// middleware added to a group apply to all of its routes, regardless of the order they were added in.
+type routeGroup struct {
+	parent     *routeGroup
+	prefix     string
+	namePrefix string
+	matchers   []Matcher
+	middleware []Middleware
+}
+
+// newRouteGroup creates a group nested within the parent with the given path prefix.
+func newRouteGroup(parent *routeGroup, prefix string) *routeGroup {
+	return &routeGroup{
+		parent: parent,
+		prefix: strings.Trim(strings.TrimSpace(prefix), "/"),
+	}
+}
+
+// path will prefix the given path with the prefixes of the group and all of its parents.
+func (group *routeGroup) path(path string) string {
+	if group == nil {
+		return path
+	}
+
+	// The trailing slash of the registered path is kept so that /docs/ and /docs can be told apart by the
+	// PathStrict and PathRedirect policies. Only the slashes joining the path to the prefix are trimmed.
+	path = strings.TrimSpace(path)
+	trailingSlash := strings.HasSuffix(path, "/") && strings.Trim(path, "/") != ""
+	path = strings.Trim(path, "/")
+
+	if group.prefix != "" {
+		if path == "" {
+			path = group.prefix
+		} else {
+			path = group.prefix + "/" + path
+		}
+	}
+
+	if trailingSlash {
+		path += "/"
+	}
+
+	return group.parent.path(path)
+}
+
+// name will prefix the given route name with the name prefixes of the group and all of its parents.
+func (group *routeGroup) name(name string) string {
+	if group == nil {
+		return name
+	}
+
+	return group.parent.name(group.namePrefix + name)
+}
+
+// matches runs the matchers of all parent groups followed by the matchers of this group.
+func (group *routeGroup) matches(route *Route, request *http.Request) bool {
+	if group == nil {
+		return true
+	}
+
+	if !group.parent.matches(route, request) {
+		return false
+	}
+
+	for _, matcher := range group.matchers {
+		if matcher.Match(route, request) == false {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allMatchers returns the matchers of all parent groups followed by the matchers of this group.
+func (group *routeGroup) allMatchers() []Matcher {
+	if group == nil {
+		return nil
+	}
+
+	return append(group.parent.allMatchers(), group.matchers...)
+}
+
+// allMiddleware returns the middleware of all parent groups followed by the middleware of this group.
+func (group *routeGroup) allMiddleware() []Middleware {
+	if group == nil {
+		return nil
+	}
+
+	return append(group.parent.allMiddleware(), group.middleware...)
+}
@@ -0,0 +1,55 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupPathIsPrefixedWithParentGroups(t *testing.T) {
+	parent := newRouteGroup(nil, "/api/")
+	group := newRouteGroup(parent, "v1")
+
+	assert.Equal(t, "api/v1/users", group.path("/users"))
+	assert.Equal(t, "api/v1", group.path("/"))
+	assert.Equal(t, "users", newRouteGroup(nil, "").path("users"))
+}
+
+func TestGroupNameIsPrefixedWithParentGroups(t *testing.T) {
+	parent := newRouteGroup(nil, "")
+	parent.namePrefix = "api."
+	group := newRouteGroup(parent, "")
+	group.namePrefix = "users."
+
+	assert.Equal(t, "api.users.show", group.name("show"))
+}
+
+func TestGroupMiddlewareIncludesParentMiddlewareFirst(t *testing.T) {
+	calls := []string{}
+
+	parent := newRouteGroup(nil, "")
+	parent.middleware = []Middleware{recordingMiddleware("parent", &calls)}
+	group := newRouteGroup(parent, "")
+	group.middleware = []Middleware{recordingMiddleware("group", &calls)}
+
+	chain(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}), group.allMiddleware()).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, []string{"parent", "group"}, calls)
+}
+
+func TestGroupMatchesRunsParentMatchers(t *testing.T) {
+	parent := newRouteGroup(nil, "")
+	parent.matchers = []Matcher{MatcherFunc(func(route *Route, request *http.Request) bool {
+		return request.Header.Get("X-Parent") != ""
+	})}
+	group := newRouteGroup(parent, "")
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.False(t, group.matches(nil, request))
+
+	request.Header.Set("X-Parent", "set")
+	assert.True(t, group.matches(nil, request))
+}
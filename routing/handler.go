@@ -0,0 +1,26 @@
+package routing
+
+import "net/http"
+
+// HandlerFunc is a handler that returns an error instead of writing error responses itself. It can be passed
+// to any of the Router registration helpers. Returned errors are passed to the error handler of the router
+// that dispatched the request.
+type HandlerFunc func(response http.ResponseWriter, request *http.Request) error
+
+// ServeHTTP calls the handler function and handles any error that it returns.
+func (fn HandlerFunc) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	if err := fn(response, request); err != nil {
+		HandleError(response, request, err)
+	}
+}
+
+// HandleError passes the error to the error handler of the router that dispatched the request. If the request
+// was not dispatched by a router the error is rendered with RenderError.
+func HandleError(response http.ResponseWriter, request *http.Request, err error) {
+	if r, ok := request.Context().Value(routerContextKey).(*router); ok {
+		r.handleError(response, request, err)
+		return
+	}
+
+	RenderError(response, request, err, false)
+}
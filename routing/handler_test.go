@@ -0,0 +1,65 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerFuncErrorsArePassedToTheRouterErrorHandler(t *testing.T) {
+	var handled error
+
+	router := NewRouter()
+	router.SetErrorHandler(func(rw http.ResponseWriter, r *http.Request, err error) {
+		handled = err
+		rw.WriteHeader(http.StatusTeapot)
+	})
+	router.Get("/", HandlerFunc(func(rw http.ResponseWriter, r *http.Request) error {
+		return Forbidden("")
+	}))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusTeapot, response.Code)
+	assert.Equal(t, Forbidden(""), handled)
+}
+
+func TestHandlerFuncErrorsAreRenderedByDefault(t *testing.T) {
+	router := NewRouter()
+	router.Get("/", HandlerFunc(func(rw http.ResponseWriter, r *http.Request) error {
+		return NotFound("The photo could not be found.")
+	}))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, "The photo could not be found.\n", response.Body.String())
+}
+
+func TestHandlerFuncsThatSucceedWriteTheirOwnResponse(t *testing.T) {
+	router := NewRouter()
+	router.Post("/", HandlerFunc(func(rw http.ResponseWriter, r *http.Request) error {
+		rw.WriteHeader(http.StatusCreated)
+		return nil
+	}))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.Equal(t, http.StatusCreated, response.Code)
+}
+
+func TestHandlerFuncErrorsAreRenderedOutsideOfARouter(t *testing.T) {
+	response := httptest.NewRecorder()
+	HandlerFunc(func(rw http.ResponseWriter, r *http.Request) error {
+		return errors.New("failed")
+	}).ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.Equal(t, "500 internal server error\n", response.Body.String())
+}
@@ -0,0 +1,196 @@
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+)
+
+// RoutesEnv is the environment variable that tells a gimli application to list its routes instead of serving
+// requests. The value holds the encoded RouteListOptions.
+const RoutesEnv = "GIMLI_ROUTES"
+
+// RouteListOptions controls how routes are listed.
+type RouteListOptions struct {
+	// Format is one of table, json or markdown.
+	Format string
+
+	// Method will only list routes that respond to the request method when set.
+	Method string
+
+	// PathPrefix will only list routes whose path starts with the prefix when set.
+	PathPrefix string
+}
+
+// Encode the options so that they can be passed to an application through RoutesEnv.
+func (options RouteListOptions) Encode() string {
+	return url.Values{
+		"format": {options.Format},
+		"method": {options.Method},
+		"path":   {options.PathPrefix},
+	}.Encode()
+}
+
+// ParseRouteListOptions decodes options that were encoded with RouteListOptions.Encode.
+func ParseRouteListOptions(encoded string) (RouteListOptions, error) {
+	values, err := url.ParseQuery(encoded)
+	if err != nil {
+		return RouteListOptions{}, err
+	}
+
+	return RouteListOptions{
+		Format:     values.Get("format"),
+		Method:     values.Get("method"),
+		PathPrefix: values.Get("path"),
+	}, nil
+}
+
+// routeListing describes a route for ListRoutes.
+type routeListing struct {
+	Methods  []string `json:"methods"`
+	Path     string   `json:"path"`
+	Name     string   `json:"name"`
+	Matchers []string `json:"matchers"`
+	Handler  string   `json:"handler"`
+}
+
+// ListRoutes writes the routes in the collection, filtered and formatted according to the options.
+func ListRoutes(output io.Writer, collection *RouteCollection, options RouteListOptions) error {
+	listings := []routeListing{}
+	method := strings.ToUpper(strings.TrimSpace(options.Method))
+
+	collection.Each(func(route *Route) bool {
+		if method != "" && !routeAllowsMethod(route, method) {
+			return true
+		}
+
+		if !strings.HasPrefix(route.Path(), options.PathPrefix) {
+			return true
+		}
+
+		listing := routeListing{
+			Methods:  route.Methods(),
+			Path:     route.Path(),
+			Name:     route.Name(),
+			Matchers: []string{},
+			Handler:  handlerName(route.Handler()),
+		}
+
+		for _, matcher := range route.Matchers() {
+			listing.Matchers = append(listing.Matchers, matcherName(matcher))
+		}
+
+		listings = append(listings, listing)
+
+		return true
+	})
+
+	switch options.Format {
+	case "", "table":
+		return writeRouteTable(output, listings)
+	case "json":
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(listings)
+	case "markdown", "md":
+		return writeRouteMarkdown(output, listings)
+	default:
+		return fmt.Errorf("Unknown format %s, expected table, json or markdown.", options.Format)
+	}
+}
+
+// writeRouteTable writes the listings as a table with aligned columns.
+func writeRouteTable(output io.Writer, listings []routeListing) error {
+	writer := tabwriter.NewWriter(output, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "METHOD\tPATH\tNAME\tMATCHERS\tHANDLER")
+	for _, listing := range listings {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\n",
+			strings.Join(listing.Methods, "|"),
+			listing.Path,
+			listing.Name,
+			strings.Join(listing.Matchers, ", "),
+			listing.Handler,
+		)
+	}
+
+	return writer.Flush()
+}
+
+// writeRouteMarkdown writes the listings as a markdown table, escaping pipes so that they do not end a cell.
+func writeRouteMarkdown(output io.Writer, listings []routeListing) error {
+	escape := strings.NewReplacer("|", `\|`).Replace
+
+	fmt.Fprintln(output, "| Method | Path | Name | Matchers | Handler |")
+	fmt.Fprintln(output, "| --- | --- | --- | --- | --- |")
+
+	for _, listing := range listings {
+		_, err := fmt.Fprintf(
+			output,
+			"| %s | `%s` | %s | %s | `%s` |\n",
+			escape(strings.Join(listing.Methods, ", ")),
+			escape(listing.Path),
+			escape(listing.Name),
+			escape(strings.Join(listing.Matchers, ", ")),
+			escape(listing.Handler),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// routeAllowsMethod checks if the route responds to the method, GET routes also respond to HEAD.
+func routeAllowsMethod(route *Route, method string) bool {
+	if route.acceptsAnyMethod() {
+		return true
+	}
+
+	for _, allowed := range route.Methods() {
+		if allowed == method || (method == http.MethodHead && allowed == http.MethodGet) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matcherName returns the description of matchers that implement fmt.Stringer or the type of any other matcher.
+func matcherName(matcher Matcher) string {
+	if stringer, ok := matcher.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	return fmt.Sprintf("%T", matcher)
+}
+
+// handlerName returns the name of the function for handler functions, the description of handlers that
+// implement fmt.Stringer or the type of any other handler.
+func handlerName(handler http.Handler) string {
+	if handler == nil {
+		return "<nil>"
+	}
+
+	if stringer, ok := handler.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	if fn, ok := handler.(http.HandlerFunc); ok {
+		if f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()); f != nil {
+			return strings.TrimSuffix(f.Name(), "-fm")
+		}
+	}
+
+	return fmt.Sprintf("%T", handler)
+}
@@ -0,0 +1,127 @@
+package routing
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type usersController struct{}
+
+func (controller *usersController) Show(rw http.ResponseWriter, r *http.Request) {}
+
+func routeCollectionForListing() *RouteCollection {
+	collection := NewRouteCollection()
+	router := NewRouterFromCollection(collection)
+
+	router.Get("/users/:id", http.HandlerFunc((&usersController{}).Show)).
+		Named("users.show").
+		Host("{tenant}.example.com")
+	router.Post("/users", http.NotFoundHandler())
+	router.Static("/assets", "public")
+
+	return collection
+}
+
+func TestRouteListOptionsCanBeEncodedAndParsed(t *testing.T) {
+	options := RouteListOptions{Format: "json", Method: "GET", PathPrefix: "/api"}
+
+	parsed, err := ParseRouteListOptions(options.Encode())
+
+	assert.Nil(t, err)
+	assert.Equal(t, options, parsed)
+}
+
+func TestListRoutesAsTable(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	err := ListRoutes(output, routeCollectionForListing(), RouteListOptions{})
+	assert.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.Regexp(t, `^METHOD\s+PATH\s+NAME\s+MATCHERS\s+HANDLER$`, lines[0])
+	assert.Regexp(t, `^GET\s+/users/:id\s+users.show\s+host\(\{tenant\}.example.com\)\s+.*\(\*usersController\).Show$`, lines[1])
+	assert.Regexp(t, `^POST\s+/users\s+net/http.NotFound$`, lines[2])
+	assert.Regexp(t, `^GET\s+/assets/\*filepath\s+\*routing.StaticHandler$`, lines[3])
+}
+
+func TestListRoutesShowsControllerReferences(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	collection := NewRouteCollection()
+	NewRouterFromCollection(collection).Get("/", Controller("controllers.welcome@Welcome"))
+
+	err := ListRoutes(output, collection, RouteListOptions{})
+	assert.Nil(t, err)
+	assert.Regexp(t, `GET\s+/\s+controllers.welcome@Welcome\n`, output.String())
+}
+
+func TestListRoutesShowsMountedHandlers(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	collection := NewRouteCollection()
+	NewRouterFromCollection(collection).Mount("/debug", http.NotFoundHandler())
+
+	err := ListRoutes(output, collection, RouteListOptions{Method: "POST"})
+	assert.Nil(t, err)
+	assert.Regexp(t, `\|DELETE\|OPTIONS\s+/debug\s+mount\(http.HandlerFunc\)\n`, output.String())
+	assert.Regexp(t, `\|DELETE\|OPTIONS\s+/debug/\*mountpath\s+mount\(http.HandlerFunc\)\n`, output.String())
+}
+
+func TestListRoutesAsJSON(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	err := ListRoutes(output, routeCollectionForListing(), RouteListOptions{Format: "json", PathPrefix: "/users/"})
+	assert.Nil(t, err)
+
+	listings := []routeListing{}
+	assert.Nil(t, json.Unmarshal(output.Bytes(), &listings))
+	assert.Len(t, listings, 1)
+	assert.Equal(t, []string{"GET"}, listings[0].Methods)
+	assert.Equal(t, "/users/:id", listings[0].Path)
+	assert.Equal(t, "users.show", listings[0].Name)
+	assert.Equal(t, []string{"host({tenant}.example.com)"}, listings[0].Matchers)
+}
+
+func TestListRoutesAsMarkdown(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	err := ListRoutes(output, routeCollectionForListing(), RouteListOptions{Format: "markdown", Method: "post"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "| Method | Path | Name | Matchers | Handler |\n"+
+		"| --- | --- | --- | --- | --- |\n"+
+		"| POST | `/users` |  |  | `net/http.NotFound` |\n", output.String())
+}
+
+func TestListRoutesAsMarkdownEscapesPipesInCodeSpans(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	collection := NewRouteCollection()
+	NewRouterFromCollection(collection).Get("/reports/{format:json|xml}", Controller("controllers.reports@Show|Export"))
+
+	err := ListRoutes(output, collection, RouteListOptions{Format: "markdown"})
+	assert.Nil(t, err)
+	assert.Contains(t, output.String(), "| GET | `/reports/{format:json\\|xml}` |  |  | `controllers.reports@Show\\|Export` |\n")
+}
+
+func TestListRoutesFiltersHeadThroughGetRoutes(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	ListRoutes(output, routeCollectionForListing(), RouteListOptions{Format: "json", Method: "HEAD"})
+
+	listings := []routeListing{}
+	json.Unmarshal(output.Bytes(), &listings)
+	assert.Len(t, listings, 2)
+}
+
+func TestListRoutesReturnsErrorForUnknownFormat(t *testing.T) {
+	err := ListRoutes(&bytes.Buffer{}, routeCollectionForListing(), RouteListOptions{Format: "xml"})
+
+	assert.EqualError(t, err, "Unknown format xml, expected table, json or markdown.")
+}
@@ -1,6 +1,13 @@
 package routing
 
-import "net/http"
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
 
 // Matcher is used to check if the given route matches a request based on the conditions set out
 // in the Match function.
@@ -27,3 +34,388 @@ func MethodMatcher(route *Route, request *http.Request) bool {
 
 	return false
 }
+
+// methodMatcher is the MethodMatcher set on every route by NewRoute.
+type methodMatcher struct{}
+
+// Match calls MethodMatcher(route, request).
+func (matcher methodMatcher) Match(route *Route, request *http.Request) bool {
+	return MethodMatcher(route, request)
+}
+
+// paramCapturer is implemented by matchers that capture named params from the request.
+type paramCapturer interface {
+	params(request *http.Request) map[string]string
+}
+
+// HostMatcher will check that the host of the request, without the port, matches a pattern. The pattern is
+// split into labels on each dot and, like path segments, labels can be named params written as {name} or
+// {name:pattern}. A label written as * matches any single label, such as *.example.com, and *name also
+// captures it as a param. The captured values are added to the url params of a matched route.
+type HostMatcher struct {
+	pattern string
+	labels  []pathSegment
+}
+
+// NewHostMatcher will create a HostMatcher for the pattern, for example {tenant}.example.com. Static labels
+// are matched case insensitively. NewHostMatcher panics if a label pattern cannot be compiled.
+func NewHostMatcher(pattern string) *HostMatcher {
+	matcher := &HostMatcher{pattern: strings.TrimSpace(pattern)}
+
+	for _, label := range strings.Split(matcher.pattern, ".") {
+		segment := parseSegment(label)
+		if !segment.isNamedParam && !segment.isWildcard {
+			segment = parseSegment(strings.ToLower(label))
+		}
+
+		matcher.labels = append(matcher.labels, segment)
+	}
+
+	return matcher
+}
+
+// Match checks the host of the request against the pattern.
+func (matcher *HostMatcher) Match(route *Route, request *http.Request) bool {
+	_, ok := matcher.capture(request)
+
+	return ok
+}
+
+// params returns the named params captured from the host of the request.
+func (matcher *HostMatcher) params(request *http.Request) map[string]string {
+	params, _ := matcher.capture(request)
+
+	return params
+}
+
+// capture matches each label of the request host and collects the named params.
+func (matcher *HostMatcher) capture(request *http.Request) (map[string]string, bool) {
+	labels := strings.Split(requestHostname(request), ".")
+	if len(labels) != len(matcher.labels) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+
+	for i, label := range matcher.labels {
+		value := labels[i]
+		if !label.isNamedParam {
+			value = strings.ToLower(value)
+		}
+
+		if label.isWildcard {
+			if value == "" {
+				return nil, false
+			}
+
+			if label.value != "" {
+				params[label.value] = value
+			}
+
+			continue
+		}
+
+		if !label.matches(value) {
+			return nil, false
+		}
+
+		if label.isNamedParam {
+			params[label.value] = value
+		}
+	}
+
+	return params, true
+}
+
+// build replaces the named params in the pattern with the given values to create a host.
+func (matcher *HostMatcher) build(params map[string]string) (string, error) {
+	labels := make([]string, len(matcher.labels))
+
+	for i, label := range matcher.labels {
+		if label.isWildcard && label.value == "" {
+			return "", errors.New("Host " + matcher.pattern + " can not be built as it contains an unnamed wildcard.")
+		}
+
+		if !label.isNamedParam && !label.isWildcard {
+			labels[i] = label.value
+			continue
+		}
+
+		value, ok := params[label.value]
+		if !ok || value == "" {
+			return "", errors.New("Param " + label.value + " is required to build the host " + matcher.pattern + ".")
+		}
+
+		if label.isNamedParam && !label.matches(value) {
+			return "", errors.New("Param " + label.value + " does not match the constraint " + label.constraint.String() + ".")
+		}
+
+		labels[i] = value
+	}
+
+	return strings.Join(labels, "."), nil
+}
+
+// String describes the matcher.
+func (matcher *HostMatcher) String() string {
+	return "host(" + matcher.pattern + ")"
+}
+
+// SchemeMatcher will check that the request was made with one of the allowed schemes, http or https.
+type SchemeMatcher struct {
+	schemes        []string
+	trustForwarded bool
+}
+
+// NewSchemeMatcher will create a SchemeMatcher that allows the given schemes.
+func NewSchemeMatcher(schemes ...string) *SchemeMatcher {
+	matcher := &SchemeMatcher{}
+
+	for _, scheme := range schemes {
+		matcher.schemes = append(matcher.schemes, strings.ToLower(strings.TrimSpace(scheme)))
+	}
+
+	return matcher
+}
+
+// SetTrustForwardedProto will make the matcher use the X-Forwarded-Proto header, when it is present, to determine
+// the scheme. This should only be enabled when the application is behind a proxy that sets the header.
+func (matcher *SchemeMatcher) SetTrustForwardedProto(trust bool) {
+	matcher.trustForwarded = trust
+}
+
+// Match checks the scheme of the request against the allowed schemes.
+func (matcher *SchemeMatcher) Match(route *Route, request *http.Request) bool {
+	scheme := requestScheme(request)
+
+	if forwarded := request.Header.Get("X-Forwarded-Proto"); matcher.trustForwarded && forwarded != "" {
+		scheme = strings.ToLower(strings.TrimSpace(strings.Split(forwarded, ",")[0]))
+	}
+
+	for _, allowed := range matcher.schemes {
+		if allowed == scheme {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String describes the matcher.
+func (matcher *SchemeMatcher) String() string {
+	return "scheme(" + strings.Join(matcher.schemes, "|") + ")"
+}
+
+// HeaderMatcher will check that a request header has a value, either exactly or matching a regular expression.
+type HeaderMatcher struct {
+	name    string
+	value   string
+	pattern *regexp.Regexp
+}
+
+// NewHeaderMatcher will create a HeaderMatcher that requires the header to equal the value. If the value is
+// empty the header only has to be present.
+func NewHeaderMatcher(name, value string) *HeaderMatcher {
+	return &HeaderMatcher{name: http.CanonicalHeaderKey(name), value: value}
+}
+
+// NewHeaderRegexMatcher will create a HeaderMatcher that requires the header to match the regular expression.
+// It panics if the expression cannot be compiled.
+func NewHeaderRegexMatcher(name, pattern string) *HeaderMatcher {
+	return &HeaderMatcher{name: http.CanonicalHeaderKey(name), pattern: regexp.MustCompile(pattern)}
+}
+
+// Match checks each value of the header on the request.
+func (matcher *HeaderMatcher) Match(route *Route, request *http.Request) bool {
+	for _, value := range request.Header[matcher.name] {
+		switch {
+		case matcher.pattern != nil:
+			if matcher.pattern.MatchString(value) {
+				return true
+			}
+		case matcher.value == "" || matcher.value == value:
+			return true
+		}
+	}
+
+	return false
+}
+
+// String describes the matcher.
+func (matcher *HeaderMatcher) String() string {
+	switch {
+	case matcher.pattern != nil:
+		return "header(" + matcher.name + "~" + matcher.pattern.String() + ")"
+	case matcher.value != "":
+		return "header(" + matcher.name + "=" + matcher.value + ")"
+	default:
+		return "header(" + matcher.name + ")"
+	}
+}
+
+// QueryMatcher will check that a query string parameter has a value.
+type QueryMatcher struct {
+	key   string
+	value string
+}
+
+// NewQueryMatcher will create a QueryMatcher that requires the query parameter to equal the value. If the value
+// is empty the parameter only has to be present.
+func NewQueryMatcher(key, value string) *QueryMatcher {
+	return &QueryMatcher{key: key, value: value}
+}
+
+// Match checks each value of the query parameter on the request.
+func (matcher *QueryMatcher) Match(route *Route, request *http.Request) bool {
+	values, ok := request.URL.Query()[matcher.key]
+	if !ok {
+		return false
+	}
+
+	if matcher.value == "" {
+		return true
+	}
+
+	for _, value := range values {
+		if value == matcher.value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String describes the matcher.
+func (matcher *QueryMatcher) String() string {
+	if matcher.value == "" {
+		return "query(" + matcher.key + ")"
+	}
+
+	return "query(" + matcher.key + "=" + matcher.value + ")"
+}
+
+// ContentTypeMatcher will check that the media type of the request body is one of the allowed types. Types can
+// use a wildcard subtype such as application/*.
+type ContentTypeMatcher struct {
+	types []string
+}
+
+// NewContentTypeMatcher will create a ContentTypeMatcher that allows the given media types.
+func NewContentTypeMatcher(types ...string) *ContentTypeMatcher {
+	return &ContentTypeMatcher{types: normaliseMediaTypes(types)}
+}
+
+// Match checks the Content-Type header of the request, ignoring any parameters such as the charset.
+func (matcher *ContentTypeMatcher) Match(route *Route, request *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range matcher.types {
+		if mediaTypeMatches(allowed, mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String describes the matcher.
+func (matcher *ContentTypeMatcher) String() string {
+	return "content-type(" + strings.Join(matcher.types, "|") + ")"
+}
+
+// AcceptMatcher will check that the client accepts one of the media types the route can respond with. Requests
+// without an Accept header accept any media type.
+type AcceptMatcher struct {
+	types []string
+}
+
+// NewAcceptMatcher will create an AcceptMatcher for the media types the route can respond with.
+func NewAcceptMatcher(types ...string) *AcceptMatcher {
+	return &AcceptMatcher{types: normaliseMediaTypes(types)}
+}
+
+// Match checks the media ranges in the Accept header of the request, ignoring any with a quality of zero.
+func (matcher *AcceptMatcher) Match(route *Route, request *http.Request) bool {
+	accept := request.Header.Get("Accept")
+	if accept == "" {
+		return true
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+
+		for _, offered := range matcher.types {
+			if mediaTypeMatches(mediaRange, offered) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// String describes the matcher.
+func (matcher *AcceptMatcher) String() string {
+	return "accept(" + strings.Join(matcher.types, "|") + ")"
+}
+
+// normaliseMediaTypes lower cases and trims the media types.
+func normaliseMediaTypes(types []string) []string {
+	normalised := []string{}
+	for _, mediaType := range types {
+		normalised = append(normalised, strings.ToLower(strings.TrimSpace(mediaType)))
+	}
+
+	return normalised
+}
+
+// mediaTypeMatches checks if the media type is covered by the media range, which may be */* or use a
+// wildcard subtype such as text/*.
+func mediaTypeMatches(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+
+	if strings.HasSuffix(mediaRange, "/*") {
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*"))
+	}
+
+	return false
+}
+
+// requestScheme returns the scheme the request was made with.
+func requestScheme(request *http.Request) string {
+	if request.TLS != nil {
+		return "https"
+	}
+
+	if request.URL.Scheme != "" {
+		return strings.ToLower(request.URL.Scheme)
+	}
+
+	return "http"
+}
+
+// requestHostname returns the host of the request without the port.
+func requestHostname(request *http.Request) string {
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+
+	if i := strings.LastIndex(host, ":"); i != -1 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+
+	return strings.Trim(host, "[]")
+}
@@ -18,3 +18,159 @@ func TestMethodMatcher(t *testing.T) {
 	request = httptest.NewRequest(http.MethodPost, "/test", nil)
 	assert.False(t, matcher.Match(route, request))
 }
+
+func TestHostMatcher(t *testing.T) {
+	matcher := NewHostMatcher("{tenant}.Example.com")
+
+	request := httptest.NewRequest(http.MethodGet, "http://acme.example.COM:8080/", nil)
+	assert.True(t, matcher.Match(nil, request))
+	assert.Equal(t, map[string]string{"tenant": "acme"}, matcher.params(request))
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	assert.False(t, matcher.Match(nil, request))
+
+	request = httptest.NewRequest(http.MethodGet, "http://acme.other.com/", nil)
+	assert.False(t, matcher.Match(nil, request))
+}
+
+func TestHostMatcherConstraints(t *testing.T) {
+	matcher := NewHostMatcher("{region:eu|us}.{tenant}.example.com")
+
+	request := httptest.NewRequest(http.MethodGet, "http://eu.acme.example.com/", nil)
+	assert.True(t, matcher.Match(nil, request))
+	assert.Equal(t, map[string]string{"region": "eu", "tenant": "acme"}, matcher.params(request))
+
+	request = httptest.NewRequest(http.MethodGet, "http://asia.acme.example.com/", nil)
+	assert.False(t, matcher.Match(nil, request))
+}
+
+func TestHostMatcherWildcardsMatchASingleLabel(t *testing.T) {
+	matcher := NewHostMatcher("*.example.com")
+
+	request := httptest.NewRequest(http.MethodGet, "http://acme.example.com/", nil)
+	assert.True(t, matcher.Match(nil, request))
+	assert.Empty(t, matcher.params(request))
+
+	request = httptest.NewRequest(http.MethodGet, "http://eu.acme.example.com/", nil)
+	assert.False(t, matcher.Match(nil, request))
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	assert.False(t, matcher.Match(nil, request))
+
+	matcher = NewHostMatcher("*tenant.example.com")
+
+	request = httptest.NewRequest(http.MethodGet, "http://acme.example.com/", nil)
+	assert.True(t, matcher.Match(nil, request))
+	assert.Equal(t, map[string]string{"tenant": "acme"}, matcher.params(request))
+}
+
+func TestHostMatcherWildcardsCanOnlyBeBuiltWhenNamed(t *testing.T) {
+	_, err := NewHostMatcher("*.example.com").build(nil)
+	assert.EqualError(t, err, "Host *.example.com can not be built as it contains an unnamed wildcard.")
+
+	host, err := NewHostMatcher("*tenant.example.com").build(map[string]string{"tenant": "acme"})
+	assert.Nil(t, err)
+	assert.Equal(t, "acme.example.com", host)
+}
+
+func TestHostMatcherBuildsHostFromParams(t *testing.T) {
+	matcher := NewHostMatcher("{tenant}.example.com")
+
+	host, err := matcher.build(map[string]string{"tenant": "acme"})
+	assert.Nil(t, err)
+	assert.Equal(t, "acme.example.com", host)
+
+	_, err = matcher.build(nil)
+	assert.EqualError(t, err, "Param tenant is required to build the host {tenant}.example.com.")
+}
+
+func TestSchemeMatcher(t *testing.T) {
+	matcher := NewSchemeMatcher("HTTPS")
+
+	assert.True(t, matcher.Match(nil, httptest.NewRequest(http.MethodGet, "https://example.com/", nil)))
+	assert.False(t, matcher.Match(nil, httptest.NewRequest(http.MethodGet, "http://example.com/", nil)))
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	request.Header.Set("X-Forwarded-Proto", "https")
+	assert.False(t, matcher.Match(nil, request))
+
+	matcher.SetTrustForwardedProto(true)
+	assert.True(t, matcher.Match(nil, request))
+
+	request.Header.Set("X-Forwarded-Proto", "http, https")
+	assert.False(t, matcher.Match(nil, request))
+}
+
+func TestHeaderMatcher(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("X-Api-Version", "2.1")
+
+	assert.True(t, NewHeaderMatcher("x-api-version", "2.1").Match(nil, request))
+	assert.False(t, NewHeaderMatcher("X-Api-Version", "2").Match(nil, request))
+	assert.True(t, NewHeaderMatcher("X-Api-Version", "").Match(nil, request))
+	assert.False(t, NewHeaderMatcher("X-Missing", "").Match(nil, request))
+
+	assert.True(t, NewHeaderRegexMatcher("X-Api-Version", `^2\.`).Match(nil, request))
+	assert.False(t, NewHeaderRegexMatcher("X-Api-Version", `^1\.`).Match(nil, request))
+}
+
+func TestQueryMatcher(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/?format=json&debug", nil)
+
+	assert.True(t, NewQueryMatcher("format", "json").Match(nil, request))
+	assert.False(t, NewQueryMatcher("format", "xml").Match(nil, request))
+	assert.True(t, NewQueryMatcher("debug", "").Match(nil, request))
+	assert.False(t, NewQueryMatcher("page", "").Match(nil, request))
+}
+
+func TestContentTypeMatcher(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/", nil)
+	request.Header.Set("Content-Type", "Application/JSON; charset=utf-8")
+
+	assert.True(t, NewContentTypeMatcher("application/json").Match(nil, request))
+	assert.True(t, NewContentTypeMatcher("text/plain", "application/*").Match(nil, request))
+	assert.False(t, NewContentTypeMatcher("text/*").Match(nil, request))
+
+	request.Header.Del("Content-Type")
+	assert.False(t, NewContentTypeMatcher("application/json").Match(nil, request))
+}
+
+func TestAcceptMatcher(t *testing.T) {
+	matcher := NewAcceptMatcher("application/json")
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	assert.True(t, matcher.Match(nil, request))
+
+	request.Header.Set("Accept", "text/html, application/*;q=0.8")
+	assert.True(t, matcher.Match(nil, request))
+
+	request.Header.Set("Accept", "text/html, */*;q=0")
+	assert.False(t, matcher.Match(nil, request))
+
+	request.Header.Set("Accept", "*/*")
+	assert.True(t, matcher.Match(nil, request))
+}
+
+func TestMatchersDescribeThemselves(t *testing.T) {
+	assert.Equal(t, "host({tenant}.example.com)", NewHostMatcher("{tenant}.example.com").String())
+	assert.Equal(t, "scheme(http|https)", NewSchemeMatcher("http", "https").String())
+	assert.Equal(t, "header(X-Version=2)", NewHeaderMatcher("x-version", "2").String())
+	assert.Equal(t, "header(X-Version~^2)", NewHeaderRegexMatcher("x-version", "^2").String())
+	assert.Equal(t, "query(format=json)", NewQueryMatcher("format", "json").String())
+	assert.Equal(t, "content-type(application/json)", NewContentTypeMatcher("application/json").String())
+	assert.Equal(t, "accept(text/html)", NewAcceptMatcher("text/html").String())
+}
+
+func TestMatchersCanBeAddedToRoutes(t *testing.T) {
+	route := NewRoute("/users", nil, nil).
+		AddMatcher(NewHeaderMatcher("X-Api-Version", "2")).
+		AddMatcher(NewAcceptMatcher("application/json"))
+
+	request := httptest.NewRequest(http.MethodGet, "/users", nil)
+	request.Header.Set("X-Api-Version", "2")
+	request.Header.Set("Accept", "application/json")
+	assert.True(t, route.Matches(request))
+
+	request.Header.Set("X-Api-Version", "1")
+	assert.False(t, route.Matches(request))
+}
@@ -0,0 +1,16 @@
+package routing
+
+import "net/http"
+
+// Middleware wraps a http.Handler with additional behaviour such as logging, authentication or recovery.
+// The returned handler should call next.ServeHTTP to continue the chain.
+type Middleware func(next http.Handler) http.Handler
+
+// chain wraps the handler in the given middleware so that the first middleware is executed first.
+func chain(handler http.Handler, middleware []Middleware) http.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	return handler
+}
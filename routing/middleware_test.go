@@ -0,0 +1,36 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			*calls = append(*calls, name)
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+func TestChainExecutesMiddlewareInOrder(t *testing.T) {
+	calls := []string{}
+
+	handler := chain(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	}), []Middleware{recordingMiddleware("first", &calls), recordingMiddleware("second", &calls)})
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, []string{"first", "second", "handler"}, calls)
+}
+
+func TestChainReturnsHandlerWhenNoMiddlewareIsGiven(t *testing.T) {
+	handler := http.FileServer(http.Dir("."))
+
+	assert.Exactly(t, handler, chain(handler, nil))
+}
@@ -0,0 +1,87 @@
+package routing
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// mountedHandler serves requests for a mounted handler with the mount prefix removed from the url path.
+type mountedHandler struct {
+	handler http.Handler
+}
+
+// String describes the mounted handler for route listings.
+func (mounted *mountedHandler) String() string {
+	return fmt.Sprintf("mount(%T)", mounted.handler)
+}
+
+// ServeHTTP replaces the url path with the part of the path after the mount prefix, keeping any trailing slash,
+// before calling the mounted handler.
+func (mounted *mountedHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	stripped := "/" + Param(request, "mountpath")
+	if strings.HasSuffix(request.URL.Path, "/") && !strings.HasSuffix(stripped, "/") {
+		stripped += "/"
+	}
+
+	copied := new(http.Request)
+	*copied = *request
+	copied.URL = new(url.URL)
+	*copied.URL = *request.URL
+	copied.URL.Path = stripped
+	copied.URL.RawPath = ""
+
+	mounted.handler.ServeHTTP(response, copied)
+}
+
+// anyMethodMatcher replaces the default method matcher on mounted routes so that requests are matched whatever
+// their method, including methods such as PROPFIND that the router has no helper for.
+type anyMethodMatcher struct{}
+
+// Match always returns true.
+func (matcher anyMethodMatcher) Match(route *Route, request *http.Request) bool {
+	return true
+}
+
+// acceptsAnyMethod checks if the route was registered with Mount and matches requests with any method.
+func (r *Route) acceptsAnyMethod() bool {
+	for _, matcher := range r.routeMatchers() {
+		if _, ok := matcher.(anyMethodMatcher); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Mount registers the handler for the prefix and every path below it, for any request method. The prefix is
+// removed from the url path before the handler is called so /debug/vars is served as /vars when mounted under
+// /debug. Other routes always take precedence over the mounted handler: a route registered for the prefix or
+// for a path below it is matched before the mounted handler for the methods it allows, and requests it does
+// not accept are passed on to the mounted handler. This makes it possible to mount a handler at the root next
+// to a / route.
+// The route for the prefix and the route for the paths below it are returned.
+func (r *router) Mount(prefix string, handler http.Handler) []*Route {
+	mounted := &mountedHandler{handler: handler}
+	prefix = strings.TrimRight(prefix, "/")
+
+	return []*Route{
+		r.mount(prefix, mounted),
+		r.mount(prefix+"/*mountpath", mounted),
+	}
+}
+
+// mount adds a route for the mounted handler that accepts any request method. The methods of the route are
+// those of Any so that they are included in Allow headers and route listings.
+func (r *router) mount(path string, mounted *mountedHandler) *Route {
+	route := NewRoute(r.group.path(path), anyMethods, mounted)
+	route.group = r.group
+	route.matchers = []Matcher{anyMethodMatcher{}}
+
+	if err := r.Add(route); err != nil {
+		panic(err)
+	}
+
+	return route
+}
@@ -0,0 +1,128 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pathEchoHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery))
+	})
+}
+
+func TestMountedHandlersReceiveThePathWithoutThePrefix(t *testing.T) {
+	router := NewRouter()
+	router.Mount("/debug/", pathEchoHandler())
+
+	assert.Equal(t, "GET /?", runRequest(http.MethodGet, "/debug", router))
+	assert.Equal(t, "GET /vars?", runRequest(http.MethodGet, "/debug/vars", router))
+	assert.Equal(t, "POST /pprof/profile?seconds=1", runRequest(http.MethodPost, "/debug/pprof/profile?seconds=1", router))
+	assert.Equal(t, "DELETE /pprof/?", runRequest(http.MethodDelete, "/debug/pprof/", router))
+}
+
+func TestMountedHandlersCanBeMountedAtTheRoot(t *testing.T) {
+	router := NewRouter()
+	router.Mount("/", pathEchoHandler())
+
+	assert.Equal(t, "GET /?", runRequest(http.MethodGet, "/", router))
+	assert.Equal(t, "GET /graphql?", runRequest(http.MethodGet, "/graphql", router))
+}
+
+func TestRoutesBelowTheMountPrefixTakePrecedence(t *testing.T) {
+	router := NewRouter()
+	router.Mount("/debug", pathEchoHandler())
+	router.Get("/debug/health", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("healthy"))
+	}))
+
+	assert.Equal(t, "healthy", runRequest(http.MethodGet, "/debug/health", router))
+	assert.Equal(t, "GET /status?", runRequest(http.MethodGet, "/debug/status", router))
+
+	assert.Equal(t, "POST /health?", runRequest(http.MethodPost, "/debug/health", router))
+}
+
+func TestMountedHandlersRespectGroupPrefixes(t *testing.T) {
+	var routes []*Route
+
+	router := NewRouter()
+	router.Group("/api", func(group Router) {
+		routes = group.Mount("/graphql", pathEchoHandler())
+	})
+
+	assert.Equal(t, "/api/graphql", routes[0].Path())
+	assert.Equal(t, "/api/graphql/*mountpath", routes[1].Path())
+	assert.Equal(t, "mount(http.HandlerFunc)", routes[0].Handler().(*mountedHandler).String())
+	assert.Equal(t, "GET /schema?", runRequest(http.MethodGet, "/api/graphql/schema", router))
+}
+
+func TestMountedHandlersAcceptAnyMethod(t *testing.T) {
+	router := NewRouter()
+	router.Mount("/dav", pathEchoHandler())
+
+	assert.Equal(t, "PROPFIND /files?", runRequest("PROPFIND", "/dav/files", router))
+	assert.Equal(t, "REPORT /?", runRequest("REPORT", "/dav", router))
+	assert.Equal(t, "OPTIONS /files?", runRequest(http.MethodOptions, "/dav/files", router))
+}
+
+func TestRoutesForTheMountPrefixTakePrecedenceForTheirMethods(t *testing.T) {
+	router := NewRouter()
+	router.Mount("/graphql", pathEchoHandler())
+	router.Match("/graphql", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("connected"))
+	}), "CONNECT")
+
+	assert.Equal(t, "connected", runRequest("CONNECT", "/graphql", router))
+	assert.Equal(t, "TRACE /?", runRequest("TRACE", "/graphql", router))
+	assert.NoError(t, router.Validate())
+
+	router.Mount("/graphql", pathEchoHandler())
+	assert.Error(t, router.Validate())
+}
+
+func TestMountedHandlersCanBeMountedAtTheRootNextToARootRoute(t *testing.T) {
+	for _, mountFirst := range []bool{true, false} {
+		router := NewRouter()
+		home := func() {
+			router.Get("/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.Header().Set("X-Home", "true")
+				rw.Write([]byte("home"))
+			}))
+		}
+
+		if !mountFirst {
+			home()
+		}
+
+		assert.NotPanics(t, func() {
+			router.Mount("", pathEchoHandler())
+		})
+
+		if mountFirst {
+			home()
+		}
+
+		assert.Equal(t, "home", runRequest(http.MethodGet, "/", router))
+		assert.Equal(t, "POST /?", runRequest(http.MethodPost, "/", router))
+		assert.Equal(t, "GET /graphql?", runRequest(http.MethodGet, "/graphql", router))
+
+		response := httptest.NewRecorder()
+		router.Dispatch(response, httptest.NewRequest(http.MethodHead, "/", nil))
+
+		assert.Equal(t, "true", response.Header().Get("X-Home"))
+		assert.Empty(t, response.Body.String())
+	}
+}
+
+func TestMountedRoutesThatDoNotMatchAreTreatedAsNotFound(t *testing.T) {
+	router := NewRouter()
+	router.Mount("/graphql", pathEchoHandler())[0].Host("api.example.com")
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest("PROPFIND", "http://example.com/graphql", nil))
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
@@ -0,0 +1,77 @@
+package routing
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ParamInt returns the named url param as an int. A 400 *HTTPError is returned if the param is not an integer,
+// so the error can be returned from a HandlerFunc as it is.
+func ParamInt(request *http.Request, name string) (int, error) {
+	value, err := parseIntParam(request, name, strconv.IntSize)
+
+	return int(value), err
+}
+
+// ParamIntBetween works like ParamInt but also returns a 400 *HTTPError if the value is not between min and
+// max inclusive.
+func ParamIntBetween(request *http.Request, name string, min, max int) (int, error) {
+	value, err := ParamInt64Between(request, name, int64(min), int64(max))
+
+	return int(value), err
+}
+
+// ParamInt64 returns the named url param as an int64. A 400 *HTTPError is returned if the param is not an
+// integer.
+func ParamInt64(request *http.Request, name string) (int64, error) {
+	return parseIntParam(request, name, 64)
+}
+
+// ParamInt64Between works like ParamInt64 but also returns a 400 *HTTPError if the value is not between min
+// and max inclusive.
+func ParamInt64Between(request *http.Request, name string, min, max int64) (int64, error) {
+	value, err := ParamInt64(request, name)
+	if err != nil {
+		return 0, err
+	}
+
+	if value < min || value > max {
+		return 0, invalidParam(name, "The "+name+" param must be between "+strconv.FormatInt(min, 10)+
+			" and "+strconv.FormatInt(max, 10)+".")
+	}
+
+	return value, nil
+}
+
+// ParamUUID returns the named url param in lower case if it is a UUID written as 32 hexadecimal digits
+// separated by hyphens. A 400 *HTTPError is returned if it is not.
+func ParamUUID(request *http.Request, name string) (string, error) {
+	value := Param(request, name)
+	if !uuidPattern.MatchString(value) {
+		return "", invalidParam(name, "The "+name+" param must be a UUID.")
+	}
+
+	return strings.ToLower(value), nil
+}
+
+// parseIntParam parses the named url param as an integer that fits in bitSize bits.
+func parseIntParam(request *http.Request, name string, bitSize int) (int64, error) {
+	value, err := strconv.ParseInt(Param(request, name), 10, bitSize)
+	if err != nil {
+		return 0, invalidParam(name, "The "+name+" param must be an integer.")
+	}
+
+	return value, nil
+}
+
+// invalidParam creates the 400 *HTTPError returned when a param can not be converted.
+func invalidParam(name, message string) *HTTPError {
+	err := BadRequest(message)
+	err.Fields = map[string][]string{name: {message}}
+
+	return err
+}
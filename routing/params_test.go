@@ -0,0 +1,64 @@
+package routing
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func requestWithParams(params map[string]string) *http.Request {
+	request, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+	return withRouteContext(request, NewRoute("/", nil, nil), params)
+}
+
+func TestParamIntConvertsParams(t *testing.T) {
+	request := requestWithParams(map[string]string{"id": "42", "name": "bob", "big": "9223372036854775807"})
+
+	value, err := ParamInt(request, "id")
+	assert.Nil(t, err)
+	assert.Equal(t, 42, value)
+
+	big, err := ParamInt64(request, "big")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9223372036854775807), big)
+
+	_, err = ParamInt(request, "name")
+	assert.Equal(t, &HTTPError{
+		Status:  http.StatusBadRequest,
+		Message: "The name param must be an integer.",
+		Fields:  map[string][]string{"name": {"The name param must be an integer."}},
+	}, err)
+
+	_, err = ParamInt64(request, "missing")
+	assert.EqualError(t, err, "The missing param must be an integer.")
+}
+
+func TestBoundedParamsMustBeWithinRange(t *testing.T) {
+	request := requestWithParams(map[string]string{"page": "5"})
+
+	value, err := ParamIntBetween(request, "page", 1, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, value)
+
+	_, err = ParamIntBetween(request, "page", 1, 4)
+	assert.EqualError(t, err, "The page param must be between 1 and 4.")
+
+	_, err = ParamInt64Between(request, "page", 6, 10)
+	assert.Equal(t, http.StatusBadRequest, err.(*HTTPError).Status)
+}
+
+func TestParamUUIDValidatesUUIDs(t *testing.T) {
+	request := requestWithParams(map[string]string{
+		"valid":   "3F2504E0-4F89-11D3-9A0C-0305E82C3301",
+		"invalid": "3f2504e04f8911d39a0c0305e82c3301",
+	})
+
+	value, err := ParamUUID(request, "valid")
+	assert.Nil(t, err)
+	assert.Equal(t, "3f2504e0-4f89-11d3-9a0c-0305e82c3301", value)
+
+	_, err = ParamUUID(request, "invalid")
+	assert.EqualError(t, err, "The invalid param must be a UUID.")
+}
@@ -0,0 +1,67 @@
+package routing
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// ErrorHandler writes the response for an error that occurred while serving a request.
+type ErrorHandler func(response http.ResponseWriter, request *http.Request, err error)
+
+// PanicReporter is called with every panic recovered by the router, before the error handler is called.
+type PanicReporter func(request *http.Request, err *PanicError)
+
+// recoverPanic converts a panic into a *PanicError which is reported and passed to the error handler. Panics
+// with http.ErrAbortHandler are repanicked so that net/http aborts the response as expected.
+func (r *router) recoverPanic(response http.ResponseWriter, request *http.Request) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	if value == http.ErrAbortHandler {
+		panic(value)
+	}
+
+	err := &PanicError{Value: value, Stack: debug.Stack()}
+
+	if r.panicReporter != nil {
+		r.panicReporter(request, err)
+	}
+
+	r.handleError(response, request, err)
+}
+
+// handleError calls the error handler set on the router or renders the error with RenderError.
+func (r *router) handleError(response http.ResponseWriter, request *http.Request, err error) {
+	if r.errorHandler != nil {
+		r.errorHandler(response, request, err)
+		return
+	}
+
+	RenderError(response, request, err, r.debug)
+}
+
+// logPanic is the default PanicReporter, it writes the panic and stack trace to the standard logger.
+func logPanic(request *http.Request, err *PanicError) {
+	log.Printf("routing: panic serving %s %s: %v\n%s", request.Method, request.URL.Path, err.Value, err.Stack)
+}
+
+// SetErrorHandler sets the handler that writes the response when a panic is recovered or a HandlerFunc returns
+// an error. Errors are rendered with RenderError by default, which includes the error details and stack trace
+// when debug mode is enabled.
+func (r *router) SetErrorHandler(handler ErrorHandler) {
+	r.root.errorHandler = handler
+}
+
+// SetDebug enables including error details and stack traces in the default error responses.
+func (r *router) SetDebug(enabled bool) {
+	r.root.debug = enabled
+}
+
+// SetPanicReporter sets the function that is called with every recovered panic so that it can be logged or
+// sent to an error tracker. Panics are written to the standard logger by default, nil disables reporting.
+func (r *router) SetPanicReporter(reporter PanicReporter) {
+	r.root.panicReporter = reporter
+}
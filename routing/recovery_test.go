@@ -0,0 +1,92 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func panickingRouter() Router {
+	router := NewRouter()
+	router.SetPanicReporter(nil)
+	router.Get("/panic", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		panic("something went wrong")
+	}))
+
+	return router
+}
+
+func TestPanicsAreRecoveredWithAnInternalServerError(t *testing.T) {
+	response := httptest.NewRecorder()
+	panickingRouter().Dispatch(response, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.Equal(t, "500 internal server error\n", response.Body.String())
+}
+
+func TestPanicDetailsAreIncludedInDebugMode(t *testing.T) {
+	router := panickingRouter()
+	router.SetDebug(true)
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.Contains(t, response.Body.String(), "Panic: something went wrong.")
+	assert.Contains(t, response.Body.String(), "goroutine")
+}
+
+func TestPanicsArePassedToTheErrorHandlerAndReporter(t *testing.T) {
+	var reported *PanicError
+
+	router := panickingRouter()
+	router.SetPanicReporter(func(r *http.Request, err *PanicError) {
+		reported = err
+	})
+	router.SetErrorHandler(func(rw http.ResponseWriter, r *http.Request, err error) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		rw.Write([]byte(err.Error()))
+	})
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.Equal(t, "Panic: something went wrong.", response.Body.String())
+	assert.Equal(t, "something went wrong", reported.Value)
+	assert.NotEmpty(t, reported.Stack)
+}
+
+func TestPanicsInMiddlewareAreRecovered(t *testing.T) {
+	router := NewRouter()
+	router.SetPanicReporter(nil)
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			panic(errors.New("middleware failed"))
+		})
+	})
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+}
+
+func TestAbortHandlerPanicsAreNotRecovered(t *testing.T) {
+	router := NewRouter()
+	router.Get("/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { runRequest(http.MethodGet, "/", router) })
+}
+
+func TestPanicErrorsUnwrapErrorValues(t *testing.T) {
+	cause := errors.New("cause")
+
+	assert.Equal(t, cause, (&PanicError{Value: cause}).Unwrap())
+	assert.Nil(t, (&PanicError{Value: "text"}).Unwrap())
+}
@@ -0,0 +1,149 @@
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errorBody is the JSON representation of an error response.
+type errorBody struct {
+	Status  int                 `json:"status"`
+	Message string              `json:"message"`
+	Fields  map[string][]string `json:"errors,omitempty"`
+	Debug   *errorDebug         `json:"debug,omitempty"`
+}
+
+// errorDebug holds the details of an error that are only rendered in debug mode.
+type errorDebug struct {
+	Error string `json:"error"`
+	Stack string `json:"stack,omitempty"`
+}
+
+// RenderError writes the response for the error. The status code and message are taken from an *HTTPError,
+// any other error results in a 500 response. The body is written as JSON or HTML when the Accept header of the
+// request prefers application/json or text/html and as plain text otherwise. The error and any stack trace of
+// a *PanicError are included when debug is true.
+func RenderError(response http.ResponseWriter, request *http.Request, err error, debug bool) {
+	body := errorBody{
+		Status:  http.StatusInternalServerError,
+		Message: strconv.Itoa(http.StatusInternalServerError) + " internal server error",
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		body.Status, body.Message, body.Fields = httpErr.Status, httpErr.Message, httpErr.Fields
+	}
+
+	if debug {
+		body.Debug = &errorDebug{Error: err.Error()}
+
+		var panicErr *PanicError
+		if errors.As(err, &panicErr) {
+			body.Debug.Stack = string(panicErr.Stack)
+		}
+	}
+
+	response.Header().Set("X-Content-Type-Options", "nosniff")
+
+	switch preferredErrorFormat(request) {
+	case "json":
+		response.Header().Set("Content-Type", "application/json; charset=utf-8")
+		response.WriteHeader(body.Status)
+		json.NewEncoder(response).Encode(body)
+	case "html":
+		response.Header().Set("Content-Type", "text/html; charset=utf-8")
+		response.WriteHeader(body.Status)
+		response.Write([]byte(body.html()))
+	default:
+		response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		response.WriteHeader(body.Status)
+		response.Write([]byte(body.text()))
+	}
+}
+
+// preferredErrorFormat returns json or html for the first of those media types accepted by the request, or
+// text if neither is accepted explicitly.
+func preferredErrorFormat(request *http.Request) string {
+	for _, part := range strings.Split(request.Header.Get("Accept"), ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+
+		switch {
+		case mediaRange == "application/json" || strings.HasSuffix(mediaRange, "+json"):
+			return "json"
+		case mediaRange == "text/html":
+			return "html"
+		}
+	}
+
+	return "text"
+}
+
+// fieldNames returns the names of the invalid fields in order.
+func (body errorBody) fieldNames() []string {
+	names := []string{}
+	for name := range body.Fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// text renders the error as plain text.
+func (body errorBody) text() string {
+	lines := []string{body.Message}
+
+	for _, name := range body.fieldNames() {
+		for _, message := range body.Fields[name] {
+			lines = append(lines, name+": "+message)
+		}
+	}
+
+	if body.Debug != nil {
+		lines = append(lines, "", body.Debug.Error)
+
+		if body.Debug.Stack != "" {
+			lines = append(lines, "", body.Debug.Stack)
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// html renders the error as an HTML page.
+func (body errorBody) html() string {
+	title := html.EscapeString(strconv.Itoa(body.Status) + " " + http.StatusText(body.Status))
+
+	page := "<!DOCTYPE html>\n<html>\n<head><title>" + title + "</title></head>\n<body>\n<h1>" + title + "</h1>\n" +
+		"<p>" + html.EscapeString(body.Message) + "</p>\n"
+
+	if len(body.Fields) > 0 {
+		page += "<ul>\n"
+		for _, name := range body.fieldNames() {
+			for _, message := range body.Fields[name] {
+				page += "<li>" + html.EscapeString(name+": "+message) + "</li>\n"
+			}
+		}
+		page += "</ul>\n"
+	}
+
+	if body.Debug != nil {
+		page += "<pre>" + html.EscapeString(body.Debug.Error+"\n\n"+body.Debug.Stack) + "</pre>\n"
+	}
+
+	return page + "</body>\n</html>\n"
+}
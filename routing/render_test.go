@@ -0,0 +1,85 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func renderErrorFor(accept string, err error, debug bool) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+
+	response := httptest.NewRecorder()
+	RenderError(response, request, err, debug)
+
+	return response
+}
+
+func TestTypedErrorsAreMappedToStatusCodes(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, renderErrorFor("", BadRequest(""), false).Code)
+	assert.Equal(t, http.StatusForbidden, renderErrorFor("", Forbidden(""), false).Code)
+	assert.Equal(t, http.StatusNotFound, renderErrorFor("", NotFound(""), false).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, renderErrorFor("", Validation(nil), false).Code)
+	assert.Equal(t, http.StatusConflict, renderErrorFor("", NewHTTPError(http.StatusConflict, ""), false).Code)
+	assert.Equal(t, http.StatusInternalServerError, renderErrorFor("", errors.New("failed"), false).Code)
+}
+
+func TestWrappedTypedErrorsAreMappedToStatusCodes(t *testing.T) {
+	err := fmt.Errorf("loading photo: %w", NotFound("The photo could not be found."))
+
+	response := renderErrorFor("", err, false)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, "The photo could not be found.\n", response.Body.String())
+}
+
+func TestErrorsAreRenderedAsJSONWhenAccepted(t *testing.T) {
+	response := renderErrorFor("application/json", Validation(map[string][]string{"name": {"The name is required."}}), false)
+
+	assert.Equal(t, "application/json; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.JSONEq(
+		t,
+		`{"status": 422, "message": "The given data was invalid.", "errors": {"name": ["The name is required."]}}`,
+		response.Body.String(),
+	)
+}
+
+func TestErrorsAreRenderedAsHTMLWhenAccepted(t *testing.T) {
+	response := renderErrorFor("text/html,application/xhtml+xml,*/*;q=0.8", Forbidden("<b>No</b> access."), false)
+
+	assert.Equal(t, "text/html; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.Contains(t, response.Body.String(), "<h1>403 Forbidden</h1>")
+	assert.Contains(t, response.Body.String(), "<p>&lt;b&gt;No&lt;/b&gt; access.</p>")
+}
+
+func TestTheFirstAcceptedErrorFormatIsUsed(t *testing.T) {
+	assert.Equal(t, "json", preferredErrorFormat(acceptRequest("application/problem+json, text/html")))
+	assert.Equal(t, "html", preferredErrorFormat(acceptRequest("application/json;q=0, text/html")))
+	assert.Equal(t, "text", preferredErrorFormat(acceptRequest("*/*")))
+	assert.Equal(t, "text", preferredErrorFormat(acceptRequest("")))
+}
+
+func TestErrorDetailsAreOnlyRenderedInDebugMode(t *testing.T) {
+	err := errors.New("database unavailable")
+
+	assert.NotContains(t, renderErrorFor("application/json", err, false).Body.String(), "database unavailable")
+	assert.JSONEq(
+		t,
+		`{"status": 500, "message": "500 internal server error", "debug": {"error": "database unavailable"}}`,
+		renderErrorFor("application/json", err, true).Body.String(),
+	)
+}
+
+func acceptRequest(accept string) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept", accept)
+
+	return request
+}
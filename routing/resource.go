@@ -0,0 +1,167 @@
+package routing
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// The actions a resource controller can implement. Each action is a method on the controller with the
+// signature of an http.HandlerFunc or a HandlerFunc.
+const (
+	ActionIndex   = "index"
+	ActionCreate  = "create"
+	ActionStore   = "store"
+	ActionShow    = "show"
+	ActionEdit    = "edit"
+	ActionUpdate  = "update"
+	ActionDestroy = "destroy"
+)
+
+// resourceAction describes the route registered for a resource action. The path is appended to the resource
+// path and :param is replaced with the resource's param.
+type resourceAction struct {
+	name    string
+	method  string
+	path    string
+	methods []string
+	api     bool
+}
+
+// resourceActions lists the resource actions in the order that their routes are registered.
+var resourceActions = []resourceAction{
+	{ActionIndex, "Index", "", []string{http.MethodGet}, true},
+	{ActionCreate, "Create", "/create", []string{http.MethodGet}, false},
+	{ActionStore, "Store", "", []string{http.MethodPost}, true},
+	{ActionShow, "Show", "/:param", []string{http.MethodGet}, true},
+	{ActionEdit, "Edit", "/:param/edit", []string{http.MethodGet}, false},
+	{ActionUpdate, "Update", "/:param", []string{http.MethodPut, http.MethodPatch}, true},
+	{ActionDestroy, "Destroy", "/:param", []string{http.MethodDelete}, true},
+}
+
+// ResourceOption changes which actions are registered by Resource and APIResource.
+type ResourceOption func(actions map[string]bool)
+
+// Only limits the registered routes to the given actions.
+func Only(actions ...string) ResourceOption {
+	return func(enabled map[string]bool) {
+		only := map[string]bool{}
+		for _, action := range actions {
+			only[action] = true
+		}
+
+		for action := range enabled {
+			enabled[action] = enabled[action] && only[action]
+		}
+	}
+}
+
+// Except prevents routes from being registered for the given actions.
+func Except(actions ...string) ResourceOption {
+	return func(enabled map[string]bool) {
+		for _, action := range actions {
+			enabled[action] = false
+		}
+	}
+}
+
+// Resource registers the conventional routes for the actions implemented by the controller. For the name photos
+// the following routes are registered when the controller has the matching method:
+//
+//	GET       /photos              Index    photos.index
+//	GET       /photos/create       Create   photos.create
+//	POST      /photos              Store    photos.store
+//	GET       /photos/:photo       Show     photos.show
+//	GET       /photos/:photo/edit  Edit     photos.edit
+//	PUT/PATCH /photos/:photo       Update   photos.update
+//	DELETE    /photos/:photo       Destroy  photos.destroy
+//
+// Nested resources can be registered with a dotted name, photos.comments registers its routes under
+// /photos/:photo/comments. The registered routes are returned in the order above.
+func (r *router) Resource(name string, controller interface{}, options ...ResourceOption) []*Route {
+	return r.resource(name, controller, false, options)
+}
+
+// APIResource works like Resource but does not register the create and edit routes as these are used to
+// display HTML forms.
+func (r *router) APIResource(name string, controller interface{}, options ...ResourceOption) []*Route {
+	return r.resource(name, controller, true, options)
+}
+
+// resource registers the routes for Resource and APIResource.
+func (r *router) resource(name string, controller interface{}, api bool, options []ResourceOption) []*Route {
+	enabled := map[string]bool{}
+	for _, action := range resourceActions {
+		enabled[action.name] = action.api || !api
+	}
+
+	for _, option := range options {
+		option(enabled)
+	}
+
+	parts := strings.Split(strings.Trim(name, "./"), ".")
+	prefix := ""
+
+	for _, parent := range parts[:len(parts)-1] {
+		prefix += "/" + parent + "/:" + singular(parent)
+	}
+
+	resource := parts[len(parts)-1]
+	base := prefix + "/" + resource
+
+	routes := []*Route{}
+
+	for _, action := range resourceActions {
+		if !enabled[action.name] {
+			continue
+		}
+
+		handler, ok := controllerMethod(controller, action.method)
+		if !ok {
+			continue
+		}
+
+		path := base + strings.Replace(action.path, ":param", ":"+singular(resource), 1)
+		route := r.add(path, handler, action.methods...).Named(strings.Join(parts, ".") + "." + action.name)
+
+		routes = append(routes, route)
+	}
+
+	return routes
+}
+
+// controllerMethod returns the named method of the controller as a handler if it has the signature of an
+// http.HandlerFunc or a HandlerFunc.
+func controllerMethod(controller interface{}, name string) (http.Handler, bool) {
+	method := reflect.ValueOf(controller).MethodByName(name)
+	if !method.IsValid() {
+		return nil, false
+	}
+
+	switch handler := method.Interface().(type) {
+	case func(http.ResponseWriter, *http.Request):
+		return http.HandlerFunc(handler), true
+	case func(http.ResponseWriter, *http.Request) error:
+		return HandlerFunc(handler), true
+	default:
+		return nil, false
+	}
+}
+
+// singular returns the singular form of a plural resource name, such as photo for photos or category for
+// categories. Names that do not look plural are returned unchanged.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "ches"),
+		strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ss"):
+		return name
+	case strings.HasSuffix(name, "s"):
+		return strings.TrimSuffix(name, "s")
+	default:
+		return name
+	}
+}
@@ -0,0 +1,140 @@
+package routing
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type photoController struct{}
+
+func (controller *photoController) Index(rw http.ResponseWriter, r *http.Request) {
+	rw.Write([]byte("index"))
+}
+
+func (controller *photoController) Create(rw http.ResponseWriter, r *http.Request) {
+	rw.Write([]byte("create"))
+}
+
+func (controller *photoController) Store(rw http.ResponseWriter, r *http.Request) {
+	rw.Write([]byte("store"))
+}
+
+func (controller *photoController) Show(rw http.ResponseWriter, r *http.Request) {
+	rw.Write([]byte("show " + Param(r, "photo")))
+}
+
+func (controller *photoController) Edit(rw http.ResponseWriter, r *http.Request) {
+	rw.Write([]byte("edit " + Param(r, "photo")))
+}
+
+func (controller *photoController) Update(rw http.ResponseWriter, r *http.Request) {
+	rw.Write([]byte("update " + Param(r, "photo")))
+}
+
+func (controller *photoController) Destroy(rw http.ResponseWriter, r *http.Request) {
+	rw.Write([]byte("destroy " + Param(r, "photo")))
+}
+
+type readOnlyController struct{}
+
+func (controller *readOnlyController) Index(rw http.ResponseWriter, r *http.Request) {}
+
+func (controller *readOnlyController) Show(rw http.ResponseWriter, r *http.Request) {}
+
+// Destroy does not have the signature of a handler so no route should be registered for it.
+func (controller *readOnlyController) Destroy() {}
+
+func routeSummaries(routes []*Route) []string {
+	summaries := []string{}
+	for _, route := range routes {
+		summaries = append(summaries, route.Name()+" "+route.Path())
+	}
+
+	return summaries
+}
+
+func TestResourceRegistersConventionalRoutes(t *testing.T) {
+	router := NewRouter()
+	routes := router.Resource("photos", &photoController{})
+
+	assert.Equal(t, []string{
+		"photos.index /photos",
+		"photos.create /photos/create",
+		"photos.store /photos",
+		"photos.show /photos/:photo",
+		"photos.edit /photos/:photo/edit",
+		"photos.update /photos/:photo",
+		"photos.destroy /photos/:photo",
+	}, routeSummaries(routes))
+
+	assert.Equal(t, []string{http.MethodPut, http.MethodPatch}, routes[5].Methods())
+
+	assert.Contains(t, runRequest(http.MethodGet, "/photos", router), "index")
+	assert.Contains(t, runRequest(http.MethodGet, "/photos/create", router), "create")
+	assert.Contains(t, runRequest(http.MethodPost, "/photos", router), "store")
+	assert.Contains(t, runRequest(http.MethodGet, "/photos/1", router), "show 1")
+	assert.Contains(t, runRequest(http.MethodGet, "/photos/1/edit", router), "edit 1")
+	assert.Contains(t, runRequest(http.MethodPatch, "/photos/1", router), "update 1")
+	assert.Contains(t, runRequest(http.MethodDelete, "/photos/1", router), "destroy 1")
+}
+
+func TestResourceOnlyRegistersImplementedActions(t *testing.T) {
+	routes := NewRouter().Resource("photos", &readOnlyController{})
+
+	assert.Equal(t, []string{"photos.index /photos", "photos.show /photos/:photo"}, routeSummaries(routes))
+}
+
+func TestAPIResourceDoesNotRegisterFormRoutes(t *testing.T) {
+	routes := NewRouter().APIResource("photos", &photoController{})
+
+	assert.Equal(t, []string{
+		"photos.index /photos",
+		"photos.store /photos",
+		"photos.show /photos/:photo",
+		"photos.update /photos/:photo",
+		"photos.destroy /photos/:photo",
+	}, routeSummaries(routes))
+}
+
+func TestResourceRoutesCanBeFiltered(t *testing.T) {
+	routes := NewRouter().Resource("photos", &photoController{}, Only(ActionIndex, ActionShow, ActionEdit))
+
+	assert.Equal(t, []string{
+		"photos.index /photos",
+		"photos.show /photos/:photo",
+		"photos.edit /photos/:photo/edit",
+	}, routeSummaries(routes))
+
+	routes = NewRouter().APIResource("photos", &photoController{}, Except(ActionStore, ActionDestroy))
+
+	assert.Equal(t, []string{
+		"photos.index /photos",
+		"photos.show /photos/:photo",
+		"photos.update /photos/:photo",
+	}, routeSummaries(routes))
+}
+
+func TestNestedResourcesAreRegisteredUnderTheirParent(t *testing.T) {
+	routes := NewRouter().Resource("categories.boxes", &readOnlyController{})
+
+	assert.Equal(t, []string{
+		"categories.boxes.index /categories/:category/boxes",
+		"categories.boxes.show /categories/:category/boxes/:box",
+	}, routeSummaries(routes))
+}
+
+func TestResourcesRespectGroupPrefixes(t *testing.T) {
+	var routes []*Route
+
+	NewRouter().Group("/admin", func(group Router) {
+		group.SetNamePrefix("admin.")
+		routes = group.Resource("photos", &readOnlyController{})
+	})
+
+	assert.Equal(t, []string{
+		"admin.photos.index /admin/photos",
+		"admin.photos.show /admin/photos/:photo",
+	}, routeSummaries(routes))
+}
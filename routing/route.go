@@ -1,24 +1,50 @@
 package routing
 
 import (
+	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 )
 
-// Route should contain all information needed to match a url path to a handler bound on the route.
+// Route should contain all information needed to match a url path to a handler bound on the route. Routes can
+// safely be configured after they have been added to a router, for example by chaining Host or Use onto
+// router.Get, while requests are being dispatched. The route can be matched before the chained calls have run,
+// so routes added while serving that must never be reached without their matchers or middleware should be
+// configured with NewRoute before they are passed to Router.Add.
 type Route struct {
+	// Guards the fields below so that the route can be configured while it is being matched.
+	mux sync.RWMutex
+
 	matchers []Matcher
 	path     string
+	segments []pathSegment
 	methods  []string
 	name     string
 	handler  http.Handler
+
+	middleware []Middleware
+
+	// Set through Host and Scheme, these are also added as matchers.
+	host   *HostMatcher
+	scheme *SchemeMatcher
+
+	// The collection the route has been added to, used to keep the named routes index in sync.
+	collection *RouteCollection
+
+	// The group the route was registered in when added through a Router.
+	group *routeGroup
+
+	// Automatic OPTIONS responses are enabled unless this is set.
+	disableAutoOptions bool
 }
 
 // NewRoute will create a new Route and set a default MethodMatcher.
 func NewRoute(path string, methods []string, handler http.Handler) *Route {
 	r := &Route{}
 
-	r.matchers = []Matcher{MatcherFunc(MethodMatcher)}
+	r.matchers = []Matcher{methodMatcher{}}
 
 	r.SetPath(path)
 
@@ -35,16 +61,49 @@ func NewRoute(path string, methods []string, handler http.Handler) *Route {
 
 // Path will return the required pattern used to match the route.
 func (r *Route) Path() string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
 	return r.path
 }
 
-// SetPath will normalise and set the pattern used to match the route.
+// pathSegments returns the parsed segments of the path.
+func (r *Route) pathSegments() []pathSegment {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	return r.segments
+}
+
+// setPath sets the path and its parsed segments.
+func (r *Route) setPath(path string, segments []pathSegment) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.path = path
+	r.segments = segments
+}
+
+// SetPath will normalise and set the pattern used to match the route. Named params can be written as :name,
+// {name} or {name:pattern} where pattern is a regular expression the whole segment must match. SetPath panics
+// if a pattern cannot be compiled. If the route has been added to a collection it is re-indexed under the new path.
 func (r *Route) SetPath(path string) {
-	r.path = "/" + strings.TrimLeft(strings.TrimSpace(path), "/")
+	path = "/" + strings.TrimLeft(strings.TrimSpace(path), "/")
+	segments := parsePath(path)
+
+	if r.collection != nil {
+		r.collection.reindex(r, path, segments)
+		return
+	}
+
+	r.setPath(path, segments)
 }
 
 // Methods will return a list of request methods that this route will respond to.
 func (r *Route) Methods() []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
 	return r.methods
 }
 
@@ -55,36 +114,297 @@ func (r *Route) SetMethods(methods ...string) {
 		formatted = append(formatted, strings.ToUpper(method))
 	}
 
+	r.mux.Lock()
 	r.methods = formatted
+	r.mux.Unlock()
 }
 
 // Name can be used to lookup a route by its name.
 func (r *Route) Name() string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
 	return r.name
 }
 
-// SetName will normalise and set the name of the route.
+// SetName will normalise and set the name of the route. If the route belongs to a group the group's name
+// prefix is prepended. If the route has been added to a collection its named routes index is updated and
+// SetName panics with a *DuplicateNameError if the name is already used by another route in the collection.
 func (r *Route) SetName(name string) {
-	r.name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ToLower(strings.TrimSpace(r.group.name(name)))
+
+	if r.collection != nil {
+		if err := r.collection.rename(r, name); err != nil {
+			panic(err)
+		}
+
+		return
+	}
+
+	r.setName(name)
+}
+
+// setName sets the name without updating the named routes index of the collection.
+func (r *Route) setName(name string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.name = name
+}
+
+// Named will set the name of the route and return the route so that calls can be chained.
+func (r *Route) Named(name string) *Route {
+	r.SetName(name)
+
+	return r
+}
+
+// Where constrains the named url param to values matching the regular expression. The expression must match
+// the whole param value and is checked while the route is being looked up, so a route whose constraint fails
+// allows other routes to be matched instead. This is the same as writing the param as {param:pattern} in the path.
+// Where panics if the route has no such param or the expression cannot be compiled.
+func (r *Route) Where(param, pattern string) *Route {
+	parts := strings.Split(r.Path(), "/")
+	found := false
+
+	for i, segment := range r.pathSegments() {
+		if segment.isNamedParam && segment.value == param {
+			parts[i+1] = "{" + param + ":" + pattern + "}"
+			found = true
+		}
+	}
+
+	if !found {
+		panic("Route " + r.Path() + " does not have the param " + param + ".")
+	}
+
+	r.SetPath(strings.Join(parts, "/"))
+
+	return r
+}
+
+// Host restricts the route to requests for hosts matching the pattern. Parts of the host can be captured as
+// named params, such as {tenant}.example.com, and are added to the url params when the route is matched.
+// See NewHostMatcher for the pattern syntax.
+func (r *Route) Host(pattern string) *Route {
+	host := NewHostMatcher(pattern)
+
+	r.mux.Lock()
+	r.host = host
+	r.mux.Unlock()
+
+	return r.AddMatcher(host)
+}
+
+// Scheme restricts the route to requests made with one of the given schemes (http or https). Use AddMatcher
+// with a SchemeMatcher that trusts the X-Forwarded-Proto header when the application is behind a proxy.
+func (r *Route) Scheme(schemes ...string) *Route {
+	scheme := NewSchemeMatcher(schemes...)
+
+	r.mux.Lock()
+	r.scheme = scheme
+	r.mux.Unlock()
+
+	return r.AddMatcher(scheme)
 }
 
 // SetHandler will set the handler that will be called if the route is matched.
 func (r *Route) SetHandler(handler http.Handler) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	r.handler = handler
 }
 
+// Handler will return the handler that will be called if the route is matched.
+func (r *Route) Handler() http.Handler {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	return r.handler
+}
+
+// Use appends middleware to the route. Route middleware is executed in the order it was added, after any
+// router and group middleware and directly around the route handler.
+func (r *Route) Use(middleware ...Middleware) *Route {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.middleware = append(r.middleware, middleware...)
+
+	return r
+}
+
+// compose returns the route handler wrapped in the group and route middleware. The inner middleware is added
+// last so that it runs directly around the handler, after every group and route middleware.
+func (r *Route) compose(inner ...Middleware) http.Handler {
+	r.mux.RLock()
+	handler, middleware := r.handler, r.middleware
+	r.mux.RUnlock()
+
+	return chain(handler, append(append(r.group.allMiddleware(), middleware...), inner...))
+}
+
+// AutoOptions reports whether the router may answer OPTIONS requests for the route's path automatically.
+func (r *Route) AutoOptions() bool {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	return !r.disableAutoOptions
+}
+
+// DisableAutoOptions opts the route out of automatic OPTIONS handling. If every route on a path has opted out,
+// OPTIONS requests will receive a method not allowed response unless a route is registered for OPTIONS.
+func (r *Route) DisableAutoOptions() *Route {
+	r.mux.Lock()
+	r.disableAutoOptions = true
+	r.mux.Unlock()
+
+	return r
+}
+
+// Matchers returns the matchers of the groups the route belongs to followed by the matchers added to the route.
+// The default MethodMatcher set by NewRoute and the matcher that lets mounted routes accept any method are not
+// included.
+func (r *Route) Matchers() []Matcher {
+	matchers := []Matcher{}
+
+	for _, matcher := range append(r.group.allMatchers(), r.routeMatchers()...) {
+		switch matcher.(type) {
+		case methodMatcher, anyMethodMatcher:
+		default:
+			matchers = append(matchers, matcher)
+		}
+	}
+
+	return matchers
+}
+
 // AddMatcher will add a Matcher to the list. These are used to check if the route matches a specific request criteria.
-func (r *Route) AddMatcher(matcher Matcher) {
+func (r *Route) AddMatcher(matcher Matcher) *Route {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	r.matchers = append(r.matchers, matcher)
+
+	return r
 }
 
-// Matches will run all matchers to check if this route matches the passed in request.
+// routeMatchers returns the matchers added to the route, without those of its groups. Matchers are only ever
+// appended, so the returned slice can be used after the lock has been released.
+func (r *Route) routeMatchers() []Matcher {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	return r.matchers
+}
+
+// Equal checks if the other route is structurally the same as this route. Routes are equal when they have the
+// same path, name, methods and matchers. Matchers that do not implement fmt.Stringer, such as a MatcherFunc, are
+// compared by their type. Handlers and middleware are not compared. A route is always equal to itself.
+func (r *Route) Equal(other *Route) bool {
+	if r == other {
+		return true
+	}
+
+	if other == nil || r.Path() != other.Path() || r.Name() != other.Name() || !sameMethods(r.Methods(), other.Methods()) {
+		return false
+	}
+
+	mine, _ := r.describeMatchers()
+	theirs, _ := other.describeMatchers()
+
+	return strings.Join(mine, "\n") == strings.Join(theirs, "\n")
+}
+
+// overlaps checks if both routes would be matched by the same request, making one of them unreachable. The
+// paths must have the same shape (param names are ignored), share a method and have the same describable
+// matchers. Routes with matchers that can not be described are assumed not to overlap. A mounted route only
+// overlaps with other mounted routes, as any other route takes precedence over it.
+func (r *Route) overlaps(other *Route) bool {
+	if len(r.segments) != len(other.segments) || r.acceptsAnyMethod() != other.acceptsAnyMethod() {
+		return false
+	}
+
+	for i, segment := range r.segments {
+		if segment.shape() != other.segments[i].shape() {
+			return false
+		}
+	}
+
+	shared := false
+	for _, method := range r.Methods() {
+		shared = shared || containsMethod(other.Methods(), method)
+	}
+
+	if !shared {
+		return false
+	}
+
+	mine, ok := r.describeMatchers()
+	theirs, otherOk := other.describeMatchers()
+
+	return ok && otherOk && strings.Join(mine, "\n") == strings.Join(theirs, "\n")
+}
+
+// describeMatchers returns a sorted description of the matchers on the route and its groups, excluding the
+// default method matcher. Matchers that do not implement fmt.Stringer are described by their type, in which
+// case the second return value is false.
+func (r *Route) describeMatchers() ([]string, bool) {
+	descriptions := []string{}
+	described := true
+
+	for _, matcher := range r.Matchers() {
+		_, ok := matcher.(fmt.Stringer)
+		described = described && ok
+
+		descriptions = append(descriptions, matcherName(matcher))
+	}
+
+	sort.Strings(descriptions)
+
+	return descriptions, described
+}
+
+// sameMethods checks if both lists contain the same methods in any order.
+func sameMethods(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, method := range a {
+		if !containsMethod(b, method) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matcherParams collects the params captured by the matchers of the route and its groups, such as the named
+// params of a HostMatcher.
+func (r *Route) matcherParams(request *http.Request) map[string]string {
+	params := map[string]string{}
+
+	for _, matcher := range append(r.group.allMatchers(), r.routeMatchers()...) {
+		if capturer, ok := matcher.(paramCapturer); ok {
+			for name, value := range capturer.params(request) {
+				params[name] = value
+			}
+		}
+	}
+
+	return params
+}
+
+// Matches will run all matchers, including those of the group the route belongs to, to check if this route
+// matches the passed in request.
 func (r *Route) Matches(request *http.Request) bool {
-	for _, matcher := range r.matchers {
+	for _, matcher := range r.routeMatchers() {
 		if matcher.Match(r, request) == false {
 			return false
 		}
 	}
 
-	return true
+	return r.group.matches(r, request)
 }
@@ -1,10 +1,21 @@
 package routing
 
-// RouteCollection provides helpful ways of dealing with collections of Routes.
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// RouteCollection provides helpful ways of dealing with collections of Routes. It is safe to add and remove
+// routes while routes are being looked up.
 type RouteCollection struct {
+	mux         sync.RWMutex
 	routes      routeTrie
 	allRoutes   []*Route
 	namedRoutes map[string]*Route
+
+	// Holds the *compiledNode built by Compile, which is used in place of the trie when looking up routes.
+	// The table is never changed once built, it is rebuilt and swapped whenever the routes change.
+	compiled atomic.Value
 }
 
 // RouteMatchGroup is used to encapsulate found routes, when looked up by path, with the parsed named params
@@ -24,32 +35,218 @@ func NewRouteCollection() *RouteCollection {
 }
 
 // Add a route to the collection. If the route has a name assigned it will be added to the
-// list of named routes.
-func (collection *RouteCollection) Add(route *Route) {
+// list of named routes. Names assigned to the route after it has been added will be kept in sync.
+//
+// A *DuplicateNameError is returned and the route is not added if the name is already used by another route.
+// Routes that overlap are not checked when they are added as they are usually configured further afterwards,
+// for example with Host or Where, use Conflicts once every route has been registered.
+//
+// If the collection has been compiled the compiled table is rebuilt and swapped in once the route has been
+// added. Routes should be fully configured before they are added while requests are being dispatched.
+func (collection *RouteCollection) Add(route *Route) error {
+	collection.mux.Lock()
+	defer collection.mux.Unlock()
+
+	if existing := collection.namedRoutes[route.Name()]; route.Name() != "" && existing != nil {
+		return &DuplicateNameError{Name: route.Name(), Route: route, Existing: existing}
+	}
+
+	route.collection = collection
+
 	if route.Name() != "" {
 		collection.namedRoutes[route.Name()] = route
 	}
 
 	collection.routes.add(route)
 	collection.allRoutes = append(collection.allRoutes, route)
+	collection.recompile()
+
+	return nil
+}
+
+// Remove takes the route out of the collection. False is returned if the route was not in the collection.
+// If the collection has been compiled the compiled table is rebuilt and swapped in once the route has been removed.
+func (collection *RouteCollection) Remove(route *Route) bool {
+	collection.mux.Lock()
+	defer collection.mux.Unlock()
+
+	for i, existing := range collection.allRoutes {
+		if existing != route {
+			continue
+		}
+
+		collection.routes.remove(route)
+		collection.allRoutes = append(collection.allRoutes[:i:i], collection.allRoutes[i+1:]...)
+
+		if route.Name() != "" && collection.namedRoutes[route.Name()] == route {
+			delete(collection.namedRoutes, route.Name())
+		}
+
+		route.collection = nil
+		collection.recompile()
+
+		return true
+	}
+
+	return false
+}
+
+// reindex moves the route to a new place in the trie under the new path.
+func (collection *RouteCollection) reindex(route *Route, path string, segments []pathSegment) {
+	collection.mux.Lock()
+	defer collection.mux.Unlock()
+
+	collection.routes.remove(route)
+	route.setPath(path, segments)
+	collection.routes.add(route)
+	collection.recompile()
+}
+
+// Conflicts returns a *DuplicateRouteError for every pair of routes in the collection that have the same path
+// shape, share a method and are not told apart by other matchers, which makes the route added last unreachable.
+// Conflicts should be checked once the routes have been fully configured, Router.Validate includes them.
+func (collection *RouteCollection) Conflicts() []*DuplicateRouteError {
+	collection.mux.RLock()
+	defer collection.mux.RUnlock()
+
+	conflicts := []*DuplicateRouteError{}
+
+	for i, route := range collection.allRoutes {
+		for _, existing := range collection.allRoutes[:i] {
+			if route.overlaps(existing) {
+				conflicts = append(conflicts, &DuplicateRouteError{Route: route, Existing: existing})
+				break
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// Has checks if the collection contains a route that is structurally equal to the given route.
+func (collection *RouteCollection) Has(route *Route) bool {
+	collection.mux.RLock()
+	defer collection.mux.RUnlock()
+
+	for _, existing := range collection.allRoutes {
+		if existing.Equal(route) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // RouteByName can be used to lookup a route by its name.
 func (collection *RouteCollection) RouteByName(name string) *Route {
+	collection.mux.RLock()
+	defer collection.mux.RUnlock()
+
 	return collection.namedRoutes[name]
 }
 
 // RoutesByPath will lookup routes in the route trie and return a RoutMatchGroup
 // containing any matched routes and the parsed params.
 func (collection *RouteCollection) RoutesByPath(path string) *RouteMatchGroup {
-	routes, params := collection.routes.search(path)
+	return collection.matchGroup(collection.search(path, false))
+}
+
+// RoutesByPathCaseInsensitive works like RoutesByPath but compares the static segments of route paths
+// case insensitively.
+func (collection *RouteCollection) RoutesByPathCaseInsensitive(path string) *RouteMatchGroup {
+	return collection.matchGroup(collection.search(path, true))
+}
+
+// matchGroup wraps the result of search in a RouteMatchGroup, the params are never nil.
+func (collection *RouteCollection) matchGroup(routes []*Route, params map[string]string) *RouteMatchGroup {
+	if params == nil {
+		params = map[string]string{}
+	}
 
 	return &RouteMatchGroup{routes, params}
 }
 
+// search looks up the routes for the path in the compiled table if the collection has been compiled or in
+// the trie otherwise. The params may be nil if the path did not contain any. Searching the compiled table
+// does not need to take the lock as the table is never changed.
+func (collection *RouteCollection) search(path string, fold bool) ([]*Route, map[string]string) {
+	return collection.searchWhere(path, fold, nil)
+}
+
+// searchWhere works like search but skips the routes of a path branch when accept returns false for them, so
+// that a branch whose routes all reject the request does not hide the routes of a lower precedence branch.
+func (collection *RouteCollection) searchWhere(path string, fold bool, accept func(routes []*Route) bool) ([]*Route, map[string]string) {
+	if compiled, ok := collection.compiled.Load().(*compiledNode); ok {
+		return compiled.searchWhere(path, fold, accept)
+	}
+
+	collection.mux.RLock()
+	defer collection.mux.RUnlock()
+
+	return collection.routes.searchWhere(path, fold, accept)
+}
+
+// searchAll returns the routes of every branch that matches the path, in order of precedence.
+func (collection *RouteCollection) searchAll(path string, fold bool) []*Route {
+	collection.mux.RLock()
+	defer collection.mux.RUnlock()
+
+	return collection.routes.searchAll(path, fold)
+}
+
+// Compile builds a read only copy of the route trie that is used to look up routes from then on. The compiled
+// table indexes static segments and pools param storage so that looking up a static path does not allocate.
+// Adding or removing routes after the collection has been compiled rebuilds the table.
+func (collection *RouteCollection) Compile() {
+	collection.mux.Lock()
+	defer collection.mux.Unlock()
+
+	collection.compiled.Store(compileTrie(collection.routes.(*trie)))
+}
+
+// Compiled reports whether Compile has been called on the collection.
+func (collection *RouteCollection) Compiled() bool {
+	_, ok := collection.compiled.Load().(*compiledNode)
+
+	return ok
+}
+
+// recompile rebuilds the compiled table if the collection has been compiled. The lock must be held.
+func (collection *RouteCollection) recompile() {
+	if _, ok := collection.compiled.Load().(*compiledNode); ok {
+		collection.compiled.Store(compileTrie(collection.routes.(*trie)))
+	}
+}
+
+// rename sets the name of a route in the collection and updates the named routes index. A *DuplicateNameError
+// is returned and the route is left unchanged if the name belongs to another route.
+func (collection *RouteCollection) rename(route *Route, name string) error {
+	collection.mux.Lock()
+	defer collection.mux.Unlock()
+
+	if existing := collection.namedRoutes[name]; name != "" && existing != nil && existing != route {
+		return &DuplicateNameError{Name: name, Route: route, Existing: existing}
+	}
+
+	if route.Name() != "" && collection.namedRoutes[route.Name()] == route {
+		delete(collection.namedRoutes, route.Name())
+	}
+
+	if name != "" {
+		collection.namedRoutes[name] = route
+	}
+
+	route.setName(name)
+
+	return nil
+}
+
 // RefreshNamedRoutes will clear the named routes list and add all named routes back from the all routes list.
-// This is useful if a name is assigned to a route after it has been added to the collection.
+// Routes keep the index in sync when they are renamed so this is only needed to rebuild the index from scratch.
 func (collection *RouteCollection) RefreshNamedRoutes() {
+	collection.mux.Lock()
+	defer collection.mux.Unlock()
+
 	collection.namedRoutes = map[string]*Route{}
 
 	for _, route := range collection.allRoutes {
@@ -59,7 +256,24 @@ func (collection *RouteCollection) RefreshNamedRoutes() {
 	}
 }
 
+// Each calls fn for every route in the collection in the order that they were added. Iteration stops
+// when fn returns false. Routes added or removed by fn do not change the routes that are iterated over.
+func (collection *RouteCollection) Each(fn func(route *Route) bool) {
+	collection.mux.RLock()
+	routes := collection.allRoutes
+	collection.mux.RUnlock()
+
+	for _, route := range routes {
+		if !fn(route) {
+			return
+		}
+	}
+}
+
 // Count will return the total number of routes in the collection.
 func (collection *RouteCollection) Count() int {
+	collection.mux.RLock()
+	defer collection.mux.RUnlock()
+
 	return len(collection.allRoutes)
 }
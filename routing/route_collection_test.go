@@ -1,6 +1,7 @@
 package routing
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -84,3 +85,186 @@ func TestCountReturnsNumberOfRoutesInCollection(t *testing.T) {
 
 	assert.Equal(t, 3, rc.Count())
 }
+
+func TestNamedRoutesAreKeptInSyncWhenRenamed(t *testing.T) {
+	rc := NewRouteCollection()
+	route := NewRoute("/", nil, nil)
+	rc.Add(route)
+
+	route.Named("home")
+	assert.Equal(t, route, rc.RouteByName("home"))
+
+	route.Named("index")
+	assert.Nil(t, rc.RouteByName("home"))
+	assert.Equal(t, route, rc.RouteByName("index"))
+}
+
+func TestAddReturnsErrorForDuplicateName(t *testing.T) {
+	rc := NewRouteCollection()
+
+	existing := NewRoute("/a", nil, nil).Named("home")
+	route := NewRoute("/b", nil, nil).Named("home")
+
+	assert.Nil(t, rc.Add(existing))
+
+	err := rc.Add(route)
+	assert.Equal(t, &DuplicateNameError{Name: "home", Route: route, Existing: existing}, err)
+	assert.EqualError(t, err, "Route name home is already used by route /a.")
+	assert.Equal(t, 1, rc.Count())
+}
+
+func TestConflictsReportsOverlappingPathAndMethods(t *testing.T) {
+	rc := NewRouteCollection()
+
+	existing := NewRoute("/users/:id", []string{http.MethodGet, http.MethodPut}, nil)
+	route := NewRoute("/users/:name", []string{http.MethodPut}, nil)
+
+	assert.Nil(t, rc.Add(existing))
+	assert.Nil(t, rc.Add(route))
+
+	conflicts := rc.Conflicts()
+	assert.Equal(t, []*DuplicateRouteError{{Route: route, Existing: existing}}, conflicts)
+	assert.EqualError(t, conflicts[0], "Route PUT /users/:name overlaps with existing route GET|PUT /users/:id.")
+}
+
+func TestConflictsAllowsRoutesThatDoNotOverlap(t *testing.T) {
+	rc := NewRouteCollection()
+
+	rc.Add(NewRoute("/users/:id", []string{http.MethodGet}, nil))
+	rc.Add(NewRoute("/users/:id", []string{http.MethodPost}, nil))
+	rc.Add(NewRoute("/users/{id:[0-9]+}", []string{http.MethodGet}, nil))
+	rc.Add(NewRoute("/users/:id", []string{http.MethodGet}, nil).Host("admin.example.com"))
+	rc.Add(NewRoute("/users/:id", []string{http.MethodGet}, nil).AddMatcher(MatcherFunc(
+		func(route *Route, request *http.Request) bool { return true },
+	)))
+
+	assert.Empty(t, rc.Conflicts())
+}
+
+func TestConflictsAreCheckedAfterRoutesHaveBeenConfigured(t *testing.T) {
+	rc := NewRouteCollection()
+
+	general := NewRoute("/x", nil, nil)
+	rc.Add(general)
+	rc.Add(NewRoute("/x", nil, nil))
+	assert.Len(t, rc.Conflicts(), 1)
+
+	rc.Each(func(route *Route) bool {
+		if route != general {
+			route.Host("b.example.com")
+		}
+
+		return true
+	})
+	assert.Empty(t, rc.Conflicts())
+
+	rc.Add(NewRoute("/u/:name", nil, nil))
+	route := NewRoute("/u/:id", nil, nil)
+	rc.Add(route)
+	assert.Len(t, rc.Conflicts(), 1)
+
+	route.Where("id", "[0-9]+")
+	assert.Empty(t, rc.Conflicts())
+}
+
+func TestConflictsCatchDuplicatesConfiguredAfterTheyWereAdded(t *testing.T) {
+	rc := NewRouteCollection()
+
+	existing := NewRoute("/x", nil, nil)
+	route := NewRoute("/x", nil, nil)
+	rc.Add(existing)
+	rc.Add(route)
+
+	existing.Host("a.example.com")
+	route.Host("a.example.com")
+
+	assert.Equal(t, []*DuplicateRouteError{{Route: route, Existing: existing}}, rc.Conflicts())
+}
+
+func TestRenamingToAnExistingNamePanics(t *testing.T) {
+	rc := NewRouteCollection()
+
+	rc.Add(NewRoute("/a", nil, nil).Named("home"))
+	route := NewRoute("/b", nil, nil)
+	rc.Add(route)
+
+	assert.Panics(t, func() {
+		route.Named("home")
+	})
+	assert.Equal(t, "", route.Name())
+}
+
+func TestChangingThePathToAnOverlappingPathIsReportedAsAConflict(t *testing.T) {
+	rc := NewRouteCollection()
+
+	route := NewRoute("/u/:x", nil, nil)
+	rc.Add(NewRoute("/u/{id:[0-9]+}", nil, nil))
+	rc.Add(route)
+	assert.Empty(t, rc.Conflicts())
+
+	route.Where("x", "[0-9]+")
+
+	conflicts := rc.Conflicts()
+	assert.Len(t, conflicts, 1)
+	assert.EqualError(t, conflicts[0], "Route GET /u/{x:[0-9]+} overlaps with existing route GET /u/{id:[0-9]+}.")
+
+	route.SetPath("/v/:x")
+	assert.Empty(t, rc.Conflicts())
+	assert.Equal(t, []*Route{route}, rc.RoutesByPath("/v/abc").Routes)
+}
+
+func TestHasComparesRoutesStructurally(t *testing.T) {
+	rc := NewRouteCollection()
+	rc.Add(NewRoute("/users/:id", []string{http.MethodGet, http.MethodHead}, nil).Named("users.show").Host("example.com"))
+
+	assert.True(t, rc.Has(NewRoute("/users/:id", []string{http.MethodHead, http.MethodGet}, nil).Named("users.show").Host("example.com")))
+	assert.False(t, rc.Has(NewRoute("/users/:id", []string{http.MethodGet}, nil).Named("users.show").Host("example.com")))
+	assert.False(t, rc.Has(NewRoute("/users/:id", []string{http.MethodGet, http.MethodHead}, nil).Named("users.show")))
+	assert.False(t, rc.Has(NewRoute("/users/:name", []string{http.MethodGet, http.MethodHead}, nil).Named("users.show").Host("example.com")))
+}
+
+func TestHasComparesMatchersWithoutADescriptionByType(t *testing.T) {
+	rc := NewRouteCollection()
+
+	custom := func(route *Route, request *http.Request) bool { return true }
+	route := NewRoute("/users/:id", nil, nil).AddMatcher(MatcherFunc(custom))
+	rc.Add(route)
+
+	assert.True(t, route.Equal(route))
+	assert.True(t, rc.Has(route))
+	assert.True(t, rc.Has(NewRoute("/users/:id", nil, nil).AddMatcher(MatcherFunc(custom))))
+	assert.False(t, rc.Has(NewRoute("/users/:id", nil, nil)))
+	assert.False(t, rc.Has(NewRoute("/users/:id", nil, nil).AddMatcher(NewHeaderMatcher("X-Api", ""))))
+}
+
+func TestEachIteratesRoutesInOrderUntilFalseIsReturned(t *testing.T) {
+	rc := NewRouteCollection()
+
+	a, b, c := NewRoute("/a", nil, nil), NewRoute("/b", nil, nil), NewRoute("/c", nil, nil)
+	rc.Add(a)
+	rc.Add(b)
+	rc.Add(c)
+
+	visited := []*Route{}
+	rc.Each(func(route *Route) bool {
+		visited = append(visited, route)
+		return route != b
+	})
+
+	assert.Equal(t, []*Route{a, b}, visited)
+}
+
+func TestRoutesCanBeRemovedFromCollection(t *testing.T) {
+	rc := NewRouteCollection()
+
+	route := NewRoute("/users", nil, nil).Named("users")
+	rc.Add(route)
+
+	assert.True(t, rc.Remove(route))
+	assert.False(t, rc.Remove(route))
+	assert.Empty(t, rc.RoutesByPath("/users").Routes)
+	assert.Nil(t, rc.RouteByName("users"))
+	assert.Equal(t, 0, rc.Count())
+
+	assert.Nil(t, rc.Add(NewRoute("/users", nil, nil).Named("users")))
+}
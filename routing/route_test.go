@@ -73,3 +73,79 @@ func TestMethodMatcherIsSetByDefault(t *testing.T) {
 	request = httptest.NewRequest(http.MethodPost, "/", nil)
 	assert.False(t, r.Matches(request))
 }
+
+func TestAutoOptionsIsEnabledByDefaultAndCanBeDisabled(t *testing.T) {
+	r := NewRoute("/test", nil, nil)
+	assert.True(t, r.AutoOptions())
+
+	r.DisableAutoOptions()
+	assert.False(t, r.AutoOptions())
+}
+
+func TestRouteSettersCanBeChained(t *testing.T) {
+	r := NewRoute("/test/:id", nil, nil).
+		Named("test").
+		Where("id", "[0-9]+").
+		Use(func(next http.Handler) http.Handler { return next }).
+		DisableAutoOptions()
+
+	assert.Equal(t, "test", r.Name())
+	assert.Len(t, r.middleware, 1)
+	assert.False(t, r.AutoOptions())
+}
+
+func TestWhereAddsConstraintToNamedParamInPath(t *testing.T) {
+	r := NewRoute("/users/:id/posts/{post}", nil, nil).Where("id", "[0-9]+").Where("post", "[a-z]+")
+
+	assert.Equal(t, "/users/{id:[0-9]+}/posts/{post:[a-z]+}", r.Path())
+	assert.True(t, r.segments[1].matches("123"))
+	assert.False(t, r.segments[1].matches("new"))
+}
+
+func TestWherePanicsWithInvalidExpressionOrUnknownParam(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRoute("/users/:id", nil, nil).Where("id", "[0-9")
+	})
+
+	assert.Panics(t, func() {
+		NewRoute("/users/:id", nil, nil).Where("name", "[a-z]+")
+	})
+}
+
+func TestSetPathReindexesRouteInCollection(t *testing.T) {
+	rc := NewRouteCollection()
+	r := NewRoute("/old", nil, nil)
+	rc.Add(r)
+
+	r.SetPath("/new")
+
+	assert.Nil(t, rc.RoutesByPath("/old").Routes)
+	assert.Equal(t, []*Route{r}, rc.RoutesByPath("/new").Routes)
+}
+
+func TestHostRestrictsRouteToHost(t *testing.T) {
+	r := NewRoute("/test", nil, nil).Host("Example.com")
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com:8080/test", nil)
+	assert.True(t, r.Matches(request))
+
+	request = httptest.NewRequest(http.MethodGet, "http://other.com/test", nil)
+	assert.False(t, r.Matches(request))
+}
+
+func TestSchemeRestrictsRouteToSchemes(t *testing.T) {
+	r := NewRoute("/test", nil, nil).Scheme("HTTPS")
+
+	request := httptest.NewRequest(http.MethodGet, "https://example.com/test", nil)
+	assert.True(t, r.Matches(request))
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/test", nil)
+	assert.False(t, r.Matches(request))
+}
+
+func TestMatchersExcludesDefaultMethodMatcher(t *testing.T) {
+	host := NewHostMatcher("example.com")
+	r := NewRoute("/test", nil, nil).AddMatcher(host)
+
+	assert.Equal(t, []Matcher{host}, r.Matchers())
+}
@@ -1,8 +1,13 @@
 package routing
 
 import (
+	"context"
 	"net/http"
+	"net/url"
 	"path"
+	"strings"
+
+	"github.com/nickbryan/gimli/di"
 )
 
 // Router manages dispatching of requests to route handlers.
@@ -10,36 +15,127 @@ type Router interface {
 	http.Handler
 
 	Dispatch(response http.ResponseWriter, request *http.Request)
+	Collection() *RouteCollection
 	SetNotFoundHandler(handler http.Handler)
+	SetMethodNotAllowedHandler(handler http.Handler)
+	SetPathPolicy(policy PathPolicy)
+	SetCaseInsensitive(enabled bool)
+	SetErrorHandler(handler ErrorHandler)
+	SetPanicReporter(reporter PanicReporter)
+	SetDebug(enabled bool)
+	SetContainer(container di.Container)
+	Validate() error
+	Compile()
+	Use(middleware ...Middleware)
+	UseFallback(middleware ...Middleware)
+
+	Group(prefix string, routes func(group Router))
+	SetNamePrefix(prefix string)
+	AddMatcher(matcher Matcher)
+
+	URL(name string, params map[string]string, query url.Values) (string, error)
 
-	Get(path string, handler http.Handler)
-	Post(path string, handler http.Handler)
-	Put(path string, handler http.Handler)
-	Patch(path string, handler http.Handler)
-	Delete(path string, handler http.Handler)
-	Any(path string, handler http.Handler)
-	Match(path string, handler http.Handler, methods ...string)
+	Bind(param string, resolver ModelResolver)
+	BindService(param, id string)
+
+	Get(path string, handler http.Handler) *Route
+	Post(path string, handler http.Handler) *Route
+	Put(path string, handler http.Handler) *Route
+	Patch(path string, handler http.Handler) *Route
+	Delete(path string, handler http.Handler) *Route
+	Any(path string, handler http.Handler) *Route
+	Match(path string, handler http.Handler, methods ...string) *Route
+	Static(prefix, root string) *Route
+	Add(route *Route) error
+	Remove(route *Route) bool
+	Resource(name string, controller interface{}, options ...ResourceOption) []*Route
+	APIResource(name string, controller interface{}, options ...ResourceOption) []*Route
+	Mount(prefix string, handler http.Handler) []*Route
 }
 
+// PathPolicy determines how request paths that are not in their canonical form, such as /users/ or //users,
+// are handled by the router.
+type PathPolicy int
+
+const (
+	// PathLenient cleans the request path before matching so that /users/ and //users are served by /users.
+	// Routes registered with a trailing slash, such as /docs/, serve both /docs and /docs/. This is the default
+	// policy.
+	PathLenient PathPolicy = iota
+
+	// PathStrict matches the request path exactly as it was received.
+	PathStrict
+
+	// PathRedirect redirects requests for non canonical paths to the cleaned path when it can be matched. The
+	// canonical path of a route registered with a trailing slash, such as /docs/, keeps the trailing slash.
+	// GET and HEAD requests receive a 301 response and other methods receive a 308 so that the method and body
+	// are preserved. The query string is kept in the redirect.
+	PathRedirect
+)
+
+// anyMethods are the request methods of routes registered with Any.
+var anyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// router is used both for the top level router and for route groups. Groups share the collection and
+// handlers of the root router but register routes in their own routeGroup.
 type router struct {
-	notFoundHandler http.Handler
-	collection      *RouteCollection
+	root  *router
+	group *routeGroup
+
+	notFoundHandler         http.Handler
+	methodNotAllowedHandler http.Handler
+	middleware              []Middleware
+	fallbacks               []Middleware
+	collection              *RouteCollection
+
+	pathPolicy      PathPolicy
+	caseInsensitive bool
+	container       di.Container
+
+	errorHandler  ErrorHandler
+	panicReporter PanicReporter
+	debug         bool
+
+	// Model resolvers keyed by param name and the container ids of those registered with BindService.
+	binders       map[string]ModelResolver
+	boundServices map[string]string
 }
 
-// NewRouter will create a new router instance with an empty collection and a default NotFoundHandler.
+// NewRouter will create a new router instance with an empty collection, a default NotFoundHandler and
+// a default MethodNotAllowedHandler.
 func NewRouter() Router {
-	return &router{
-		notFoundHandler: http.NotFoundHandler(),
-		collection:      NewRouteCollection(),
-	}
+	return NewRouterFromCollection(NewRouteCollection())
 }
 
-// NewRouterFromCollection will create a new router instance with a default NotFoundHandler and set the collection.
+// NewRouterFromCollection will create a new router instance with a default NotFoundHandler and
+// MethodNotAllowedHandler and set the collection.
 func NewRouterFromCollection(collection *RouteCollection) Router {
-	return &router{
-		notFoundHandler: http.NotFoundHandler(),
-		collection:      collection,
+	r := &router{
+		group:                   newRouteGroup(nil, ""),
+		notFoundHandler:         http.NotFoundHandler(),
+		methodNotAllowedHandler: MethodNotAllowedHandler(),
+		collection:              collection,
+		panicReporter:           logPanic,
 	}
+	r.root = r
+
+	return r
+}
+
+// MethodNotAllowedHandler returns a simple request handler that replies to each request
+// with a “405 method not allowed” reply.
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		http.Error(response, "405 method not allowed", http.StatusMethodNotAllowed)
+	})
 }
 
 // ServeHttp allows the router to be passed into http.ListenAndServe.
@@ -47,65 +143,411 @@ func (r *router) ServeHTTP(response http.ResponseWriter, request *http.Request)
 	r.Dispatch(response, request)
 }
 
-// Dispatch is the heart of the router.
+// Dispatch is the heart of the router. The handler resolved for the request is wrapped in the router's
+// middleware before it is served. Panics raised while serving the request are recovered and passed to the
+// error handler, which is also used for the errors returned by a HandlerFunc.
 func (r *router) Dispatch(response http.ResponseWriter, request *http.Request) {
-	url := path.Clean(request.URL.Path)
+	defer r.root.recoverPanic(response, request)
+
+	request = request.WithContext(context.WithValue(request.Context(), routerContextKey, r.root))
+
+	handler, request := r.root.resolve(request)
+
+	chain(handler, r.root.middleware).ServeHTTP(response, request)
+}
+
+// resolve finds the handler that should serve the request. If a route is matched the returned request will
+// carry the route and its url params in its context.
+func (r *router) resolve(request *http.Request) (http.Handler, *http.Request) {
+	requestPath := request.URL.Path
+	if r.pathPolicy != PathStrict {
+		requestPath = path.Clean(requestPath)
+	}
+
+	routes, params := r.lookup(requestPath, false, request)
+	fold := false
+
+	// Cleaning removes the trailing slash, so the slash is added back to find routes registered with one.
+	if len(routes) == 0 && r.pathPolicy != PathStrict && requestPath != "/" {
+		if routes, params = r.lookup(requestPath+"/", false, request); len(routes) > 0 {
+			requestPath += "/"
+		}
+	}
+
+	if len(routes) == 0 && r.caseInsensitive {
+		routes, params = r.lookup(requestPath, true, request)
+
+		if len(routes) > 0 {
+			fold = true
+			fixed := fixCase(requestPath, routes[0])
+			if r.pathPolicy == PathRedirect {
+				return redirectHandler(fixed), request
+			}
+
+			requestPath = fixed
+		}
+	}
+
+	if r.pathPolicy == PathRedirect && len(routes) > 0 && requestPath != request.URL.Path {
+		return redirectHandler(requestPath), request
+	}
+
+	if route, head := selectRoute(routes, request); route != nil {
+		handler := route.compose(r.bindModels)
+
+		if head {
+			get := handler
+			handler = http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				get.ServeHTTP(&headResponseWriter{response}, request)
+			})
+		}
+
+		return handler, withRouteContext(request, route, params)
+	}
+
+	if len(routes) == 0 {
+		return chain(r.notFoundHandler, r.fallbacks), request
+	}
+
+	// The routes of every branch that matches the path are used so that the Allow header lists all of the
+	// methods that the path can be requested with.
+	routes = r.collection.searchAll(requestPath, fold)
+	methods := allowedMethods(routes)
+	allowed := strings.Join(methods, ", ")
+
+	if request.Method == http.MethodOptions && containsMethod(methods, http.MethodOptions) {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			response.Header().Set("Allow", allowed)
+			response.WriteHeader(http.StatusNoContent)
+		}), request
+	}
+
+	// Routes that allow the method but fail on other matchers are treated as not found rather than
+	// method not allowed. Mounted handlers allow every method.
+	if !containsMethod(methods, request.Method) && !anyRouteAcceptsAnyMethod(routes) {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			response.Header().Set("Allow", allowed)
+			r.methodNotAllowedHandler.ServeHTTP(response, request)
+		}), request
+	}
+
+	return r.notFoundHandler, request
+}
+
+// lookup searches the collection for the path. The first branch of the path with a route that matches the
+// request is preferred, so that a branch whose routes reject the request does not hide a lower precedence
+// branch. If no route matches the request the routes of the first branch that matches the path are returned.
+func (r *router) lookup(path string, fold bool, request *http.Request) ([]*Route, map[string]string) {
+	routes, params := r.collection.searchWhere(path, fold, func(routes []*Route) bool {
+		route, _ := selectRoute(routes, request)
+		return route != nil
+	})
+
+	if len(routes) == 0 {
+		routes, params = r.collection.search(path, fold)
+	}
+
+	return routes, params
+}
+
+// selectRoute returns the route that should serve the request. HEAD requests are answered by GET routes when
+// no route has been registered for HEAD explicitly, in which case head is true and the handler should still
+// receive the HEAD request but anything written to the body is discarded. A GET route is also preferred over
+// a mounted handler on the same path, as it is for other methods.
+func selectRoute(routes []*Route, request *http.Request) (route *Route, head bool) {
+	route = matchRoute(routes, request)
+
+	if request.Method == http.MethodHead && (route == nil || route.acceptsAnyMethod()) {
+		if get := matchRoute(routes, withMethod(request, http.MethodGet)); get != nil && (route == nil || !get.acceptsAnyMethod()) {
+			return get, true
+		}
+	}
+
+	return route, false
+}
+
+// redirectHandler redirects the request to the given path, keeping the query string. GET and HEAD requests
+// are permanently moved while other methods receive a permanent redirect so that the method is preserved.
+func redirectHandler(location string) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		target := url.URL{Path: location, RawQuery: request.URL.RawQuery}
+
+		status := http.StatusPermanentRedirect
+		if request.Method == http.MethodGet || request.Method == http.MethodHead {
+			status = http.StatusMovedPermanently
+		}
+
+		http.Redirect(response, request, target.String(), status)
+	})
+}
+
+// fixCase replaces the static segments of the request path with those of the route so that the path has the
+// same case as the route it was matched to.
+func fixCase(requestPath string, route *Route) string {
+	parts := strings.Split(requestPath, "/")
+
+	for i, segment := range route.pathSegments() {
+		if segment.isWildcard {
+			break
+		}
+
+		if !segment.isNamedParam && i+1 < len(parts) {
+			parts[i+1] = segment.value
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// matchRoute returns the first route that matches the request or nil if none match. Routes registered with
+// Mount are only returned if no other route matches.
+func matchRoute(routes []*Route, request *http.Request) *Route {
+	var mounted *Route
+
+	for _, route := range routes {
+		if !route.Matches(request) {
+			continue
+		}
+
+		if !route.acceptsAnyMethod() {
+			return route
+		}
 
-	routeCollection := r.collection.RoutesByPath(url)
+		if mounted == nil {
+			mounted = route
+		}
+	}
 
-	handler := r.notFoundHandler
+	return mounted
+}
 
-	if len(routeCollection.Routes) > 0 {
-		for _, route := range routeCollection.Routes {
-			if route.Matches(request) {
-				handler = route.handler
-				break
+// anyRouteAcceptsAnyMethod checks if any of the routes was registered with Mount.
+func anyRouteAcceptsAnyMethod(routes []*Route) bool {
+	for _, route := range routes {
+		if route.acceptsAnyMethod() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withMethod returns a shallow copy of the request with the method replaced so that it can be used for matching.
+func withMethod(request *http.Request, method string) *http.Request {
+	copied := *request
+	copied.Method = method
+
+	return &copied
+}
+
+// containsMethod checks if the method is in the list of methods.
+func containsMethod(methods []string, method string) bool {
+	for _, allowed := range methods {
+		if allowed == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowedMethods returns the union of the request methods of all routes in the order that they were first seen.
+// HEAD is implied by GET and OPTIONS is implied by any route that has automatic OPTIONS handling enabled.
+func allowedMethods(routes []*Route) []string {
+	seen := map[string]bool{}
+	methods := []string{}
+
+	add := func(method string) {
+		if !seen[method] {
+			seen[method] = true
+			methods = append(methods, method)
+		}
+	}
+
+	for _, route := range routes {
+		for _, method := range route.Methods() {
+			add(method)
+
+			if method == http.MethodGet {
+				add(http.MethodHead)
 			}
 		}
+
+		if route.AutoOptions() {
+			add(http.MethodOptions)
+		}
 	}
 
-	handler.ServeHTTP(response, request)
+	return methods
+}
+
+// headResponseWriter discards anything written to the body so that GET handlers can answer HEAD requests
+// with headers only.
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+// Write reports the bytes as written without sending them to the client.
+func (writer *headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// Collection returns the collection that routes are registered in.
+func (r *router) Collection() *RouteCollection {
+	return r.collection
 }
 
 // SetNotFoundHandler sets the handler to be called when no routes are matched. This is http.NotFoundHandler
 // by default.
 func (r *router) SetNotFoundHandler(handler http.Handler) {
-	r.notFoundHandler = handler
+	r.root.notFoundHandler = handler
+}
+
+// SetMethodNotAllowedHandler sets the handler to be called when routes are found for the request path but none of
+// them respond to the request method. The Allow header is set on the response before the handler is called.
+// This is MethodNotAllowedHandler by default.
+func (r *router) SetMethodNotAllowedHandler(handler http.Handler) {
+	r.root.methodNotAllowedHandler = handler
+}
+
+// Compile converts the routes registered with the router into a read only table that is faster to search.
+// Compile should be called once all routes have been registered as the table is rebuilt whenever routes are
+// added or removed.
+func (r *router) Compile() {
+	r.collection.Compile()
+}
+
+// SetPathPolicy sets how request paths that are not in their canonical form are handled. This is PathLenient
+// by default.
+func (r *router) SetPathPolicy(policy PathPolicy) {
+	r.root.pathPolicy = policy
+}
+
+// SetCaseInsensitive enables matching the static segments of route paths case insensitively when no route
+// matches the request path exactly. With the PathRedirect policy the request is redirected to the path with the
+// case of the matched route instead of being served.
+func (r *router) SetCaseInsensitive(enabled bool) {
+	r.root.caseInsensitive = enabled
+}
+
+// Use appends middleware to the router. Router middleware wraps every request, including those served by the
+// not found and method not allowed handlers, and is executed in the order it was added before any route middleware.
+// When called on a group the middleware will only wrap the routes registered within that group.
+func (r *router) Use(middleware ...Middleware) {
+	if r.root != r {
+		r.group.middleware = append(r.group.middleware, middleware...)
+		return
+	}
+
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// UseFallback appends middleware that wraps the not found handler when no route matches the request path. This
+// allows responses, such as static files, to be served for paths that no route has been registered for while
+// making sure that routes always take precedence. Fallbacks are executed in the order they were added.
+func (r *router) UseFallback(middleware ...Middleware) {
+	r.root.fallbacks = append(r.root.fallbacks, middleware...)
+}
+
+// Group creates a Router that prefixes the path of every route registered through it. Name prefixes, matchers
+// and middleware set on the group apply to all of its routes. Groups can be nested and all routes are stored in
+// the same collection as the parent router.
+func (r *router) Group(prefix string, routes func(group Router)) {
+	routes(&router{
+		root:       r.root,
+		group:      newRouteGroup(r.group, prefix),
+		collection: r.collection,
+	})
+}
+
+// SetNamePrefix sets the prefix that will be prepended to the name of every route registered through the router.
+func (r *router) SetNamePrefix(prefix string) {
+	r.group.namePrefix = prefix
+}
+
+// AddMatcher adds a Matcher that every route registered through the router must satisfy.
+func (r *router) AddMatcher(matcher Matcher) {
+	r.group.matchers = append(r.group.matchers, matcher)
+}
+
+// add creates a route within the router's group and adds it to the collection. Like http.ServeMux, add panics
+// if the route name is already used as this is a programming error that should be caught when the application
+// boots. Overlapping routes are reported by Validate once the routes have been configured.
+func (r *router) add(path string, handler http.Handler, methods ...string) *Route {
+	route := NewRoute(r.group.path(path), methods, handler)
+	route.group = r.group
+
+	if err := r.Add(route); err != nil {
+		panic(err)
+	}
+
+	return route
+}
+
+// Add adds a route created with NewRoute to the collection. Unlike the registration helpers the route can be
+// fully configured before it is added, which makes Add safe to use while requests are being dispatched. When
+// called on a group the route is registered within the group, so the group's path and name prefixes are applied
+// and its matchers and middleware are used. A duplicate name is returned as an error, see RouteCollection.Add.
+func (r *router) Add(route *Route) error {
+	if action, ok := route.Handler().(*ControllerAction); ok {
+		action.router = r.root
+	}
+
+	if route.group == nil && route.collection == nil {
+		route.group = r.group
+		route.SetPath(r.group.path(route.Path()))
+
+		if name := route.Name(); name != "" {
+			route.SetName(name)
+		}
+	}
+
+	return r.collection.Add(route)
+}
+
+// Remove takes the route out of the collection so that it is no longer matched. It is safe to remove routes
+// while requests are being dispatched. False is returned if the route was not registered.
+func (r *router) Remove(route *Route) bool {
+	return r.collection.Remove(route)
 }
 
 // Get is a helper that adds a route to the collection that will match the request the method GET.
-func (r *router) Get(path string, handler http.Handler) {
-	r.collection.Add(NewRoute(path, []string{http.MethodGet}, handler))
+func (r *router) Get(path string, handler http.Handler) *Route {
+	return r.add(path, handler, http.MethodGet)
 }
 
 // Post is a helper that adds a route to the collection that will match the request the method POST.
-func (r *router) Post(path string, handler http.Handler) {
-	r.collection.Add(NewRoute(path, []string{http.MethodPost}, handler))
+func (r *router) Post(path string, handler http.Handler) *Route {
+	return r.add(path, handler, http.MethodPost)
 }
 
 // Put is a helper that adds a route to the collection that will match the request the method PUT.
-func (r *router) Put(path string, handler http.Handler) {
-	r.collection.Add(NewRoute(path, []string{http.MethodPut}, handler))
+func (r *router) Put(path string, handler http.Handler) *Route {
+	return r.add(path, handler, http.MethodPut)
 }
 
 // Patch is a helper that adds a route to the collection that will match the request the method PATCH.
-func (r *router) Patch(path string, handler http.Handler) {
-	r.collection.Add(NewRoute(path, []string{http.MethodPatch}, handler))
+func (r *router) Patch(path string, handler http.Handler) *Route {
+	return r.add(path, handler, http.MethodPatch)
 }
 
 // Delete is a helper that adds a route to the collection that will match the request method DELETE.
-func (r *router) Delete(path string, handler http.Handler) {
-	r.collection.Add(NewRoute(path, []string{http.MethodDelete}, handler))
+func (r *router) Delete(path string, handler http.Handler) *Route {
+	return r.add(path, handler, http.MethodDelete)
 }
 
 // Any is a helper that adds a route to the collection that will match any request method.
-func (r *router) Any(path string, handler http.Handler) {
-	methods := []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
-
-	r.collection.Add(NewRoute(path, methods, handler))
+func (r *router) Any(path string, handler http.Handler) *Route {
+	return r.add(path, handler, anyMethods...)
 }
 
 // Match is a helper that adds a route to the collection that will match the given request methods.
-func (r *router) Match(path string, handler http.Handler, methods ...string) {
-	r.collection.Add(NewRoute(path, methods, handler))
+func (r *router) Match(path string, handler http.Handler, methods ...string) *Route {
+	return r.add(path, handler, methods...)
+}
+
+// Static is a helper that adds a GET route to the collection that serves the files within the root directory
+// under the given path prefix.
+func (r *router) Static(prefix, root string) *Route {
+	return r.add(strings.TrimRight(prefix, "/")+"/*filepath", NewStaticHandler(root), http.MethodGet)
 }
@@ -43,6 +43,583 @@ func TestCustomNotFoundHandlerCanBeSet(t *testing.T) {
 	assert.Contains(t, response.Body.String(), "This is the custom text")
 }
 
+func TestDispatchCallsMethodNotAllowedHandlerWhenPathMatchesButMethodDoesNot(t *testing.T) {
+	router := NewRouter()
+	router.Get("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.Match("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}), http.MethodPost, http.MethodPut)
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodDelete, "/test", nil)
+
+	router.Dispatch(response, request)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "GET, HEAD, OPTIONS, POST, PUT", response.Header().Get("Allow"))
+	assert.Contains(t, response.Body.String(), "405 method not allowed")
+}
+
+func TestCustomMethodNotAllowedHandlerCanBeSet(t *testing.T) {
+	router := NewRouter()
+	router.Post("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.SetMethodNotAllowedHandler(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		response.WriteHeader(http.StatusTeapot)
+		response.Write([]byte("Allowed: " + response.Header().Get("Allow")))
+	}))
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+	router.Dispatch(response, request)
+
+	assert.Equal(t, http.StatusTeapot, response.Code)
+	assert.Contains(t, response.Body.String(), "Allowed: POST, OPTIONS")
+}
+
+func TestNotFoundHandlerIsCalledWhenMethodIsAllowedButOtherMatchersFail(t *testing.T) {
+	route := NewRoute("/test", nil, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	route.AddMatcher(MatcherFunc(func(route *Route, request *http.Request) bool {
+		return false
+	}))
+
+	routeCollection := NewRouteCollection()
+	routeCollection.Add(route)
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+	NewRouterFromCollection(routeCollection).Dispatch(response, request)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Empty(t, response.Header().Get("Allow"))
+}
+
+func TestGetRoutesAnswerHeadRequestsWithoutABody(t *testing.T) {
+	var method string
+
+	router := NewRouter()
+	router.Get("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		rw.Header().Set("X-Test", "set")
+		rw.Write([]byte("The GET handler was called."))
+	}))
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodHead, "/test", nil)
+
+	router.Dispatch(response, request)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, http.MethodHead, method)
+	assert.Equal(t, "set", response.Header().Get("X-Test"))
+	assert.Empty(t, response.Body.String())
+}
+
+func TestExplicitHeadRouteTakesPriorityOverGetRoute(t *testing.T) {
+	router := NewRouter()
+	router.Get("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The GET handler was called."))
+	}))
+	router.Match("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The HEAD handler was called."))
+	}), http.MethodHead)
+
+	assert.Contains(t, runRequest(http.MethodHead, "/test", router), "The HEAD handler was called.")
+}
+
+func TestOptionsRequestsAreAnsweredAutomatically(t *testing.T) {
+	router := NewRouter()
+	router.Get("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.Post("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodOptions, "/test", nil)
+
+	router.Dispatch(response, request)
+
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Equal(t, "GET, HEAD, OPTIONS, POST", response.Header().Get("Allow"))
+}
+
+func TestExplicitOptionsRouteTakesPriorityOverAutomaticResponse(t *testing.T) {
+	router := NewRouter()
+	router.Get("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.Match("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The OPTIONS handler was called."))
+	}), http.MethodOptions)
+
+	assert.Contains(t, runRequest(http.MethodOptions, "/test", router), "The OPTIONS handler was called.")
+}
+
+func TestRoutesCanOptOutOfAutomaticOptions(t *testing.T) {
+	route := NewRoute("/test", nil, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	route.DisableAutoOptions()
+
+	routeCollection := NewRouteCollection()
+	routeCollection.Add(route)
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodOptions, "/test", nil)
+
+	NewRouterFromCollection(routeCollection).Dispatch(response, request)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "GET, HEAD", response.Header().Get("Allow"))
+}
+
+func TestRouterAndRouteMiddlewareAreExecutedInOrderAroundTheHandler(t *testing.T) {
+	calls := []string{}
+
+	route := NewRoute("/test", nil, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	}))
+	route.Use(recordingMiddleware("route1", &calls), recordingMiddleware("route2", &calls))
+
+	routeCollection := NewRouteCollection()
+	routeCollection.Add(route)
+
+	router := NewRouterFromCollection(routeCollection)
+	router.Use(recordingMiddleware("router1", &calls))
+	router.Use(recordingMiddleware("router2", &calls))
+
+	runRequest(http.MethodGet, "/test", router)
+
+	assert.Equal(t, []string{"router1", "router2", "route1", "route2", "handler"}, calls)
+}
+
+func TestRouterMiddlewareWrapsNotFoundAndMethodNotAllowedHandlers(t *testing.T) {
+	calls := []string{}
+
+	router := NewRouter()
+	router.Post("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.Use(recordingMiddleware("router", &calls))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/test", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+
+	assert.Equal(t, []string{"router", "router"}, calls)
+}
+
+func TestFallbackMiddlewareOnlyWrapsNotFoundWhenNoRouteMatchesThePath(t *testing.T) {
+	calls := []string{}
+
+	router := NewRouter()
+	router.Post("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.UseFallback(recordingMiddleware("fallback1", &calls), recordingMiddleware("fallback2", &calls))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodPost, "/test", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/test", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Empty(t, calls)
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, []string{"fallback1", "fallback2"}, calls)
+}
+
+func TestRouterMiddlewareCanAccessTheCurrentRoute(t *testing.T) {
+	var current *Route
+
+	router := NewRouter()
+	router.Get("/test", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			current = CurrentRoute(r)
+			next.ServeHTTP(rw, r)
+		})
+	})
+
+	runRequest(http.MethodGet, "/test", router)
+
+	assert.Equal(t, "/test", current.Path())
+}
+
+func TestGroupPrefixesRoutePaths(t *testing.T) {
+	router := NewRouter()
+	router.Group("/api", func(group Router) {
+		group.Group("v1", func(group Router) {
+			group.Get("/users", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.Write([]byte("The users handler was called."))
+			}))
+			group.Get("/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.Write([]byte("The index handler was called."))
+			}))
+		})
+	})
+
+	assert.Contains(t, runRequest(http.MethodGet, "/api/v1/users", router), "The users handler was called.")
+	assert.Contains(t, runRequest(http.MethodGet, "/api/v1", router), "The index handler was called.")
+	assert.Contains(t, runRequest(http.MethodGet, "/users", router), "404 page not found")
+}
+
+func TestGroupPrependsNamePrefixToRouteNames(t *testing.T) {
+	routeCollection := NewRouteCollection()
+
+	router := NewRouterFromCollection(routeCollection)
+	router.Group("/users", func(group Router) {
+		group.SetNamePrefix("users.")
+		group.Get("/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	})
+
+	route := routeCollection.RoutesByPath("/users/1").Routes[0]
+	route.SetName("show")
+	routeCollection.RefreshNamedRoutes()
+
+	assert.Equal(t, "users.show", route.Name())
+	assert.Equal(t, route, routeCollection.RouteByName("users.show"))
+}
+
+func TestGroupMatchersApplyToEveryRouteInTheGroup(t *testing.T) {
+	router := NewRouter()
+	router.Group("/admin", func(group Router) {
+		group.Get("/dashboard", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("The dashboard handler was called."))
+		}))
+		group.AddMatcher(MatcherFunc(func(route *Route, request *http.Request) bool {
+			return request.Header.Get("X-Admin") == "true"
+		}))
+	})
+
+	assert.Contains(t, runRequest(http.MethodGet, "/admin/dashboard", router), "404 page not found")
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/admin/dashboard", nil)
+	request.Header.Set("X-Admin", "true")
+	router.Dispatch(response, request)
+
+	assert.Contains(t, response.Body.String(), "The dashboard handler was called.")
+}
+
+func TestGroupMiddlewareOnlyWrapsRoutesInTheGroup(t *testing.T) {
+	calls := []string{}
+
+	router := NewRouter()
+	router.Use(recordingMiddleware("router", &calls))
+	router.Get("/outside", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "outside")
+	}))
+	router.Group("/api", func(group Router) {
+		group.Use(recordingMiddleware("group", &calls))
+		group.Get("/inside", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "inside")
+		}))
+	})
+
+	runRequest(http.MethodGet, "/outside", router)
+	runRequest(http.MethodGet, "/api/inside", router)
+
+	assert.Equal(t, []string{"router", "outside", "router", "group", "inside"}, calls)
+}
+
+func TestRoutesAddedToAGroupAreRegisteredWithinTheGroup(t *testing.T) {
+	calls := []string{}
+	route := NewRoute("/users/", nil, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	})).Named("index")
+
+	router := NewRouter()
+	router.Group("/api", func(group Router) {
+		group.SetNamePrefix("api.")
+		group.Use(recordingMiddleware("group", &calls))
+		group.AddMatcher(MatcherFunc(func(route *Route, request *http.Request) bool {
+			return request.Header.Get("X-Api") == "true"
+		}))
+
+		assert.NoError(t, group.Add(route))
+	})
+
+	assert.Equal(t, "/api/users/", route.Path())
+	assert.Equal(t, "api.index", route.Name())
+	assert.Equal(t, route, router.Collection().RouteByName("api.index"))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/api/users/", nil))
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/users/", nil)
+	request.Header.Set("X-Api", "true")
+	router.Dispatch(httptest.NewRecorder(), request)
+
+	assert.Equal(t, []string{"group", "handler"}, calls)
+}
+
+func TestRegistrationHelpersReturnTheCreatedRoute(t *testing.T) {
+	routeCollection := NewRouteCollection()
+	router := NewRouterFromCollection(routeCollection)
+
+	route := router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {})).
+		Named("users.show")
+
+	assert.Equal(t, "/users/:id", route.Path())
+	assert.Equal(t, route, routeCollection.RouteByName("users.show"))
+}
+
+func TestRouteWhereConstraintsAreAppliedWhenDispatching(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The show handler was called."))
+	})).Where("id", "[0-9]+")
+	router.Get("/users/{slug:[a-z]+}", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The slug handler was called with " + Param(r, "slug") + "."))
+	}))
+
+	assert.Contains(t, runRequest(http.MethodGet, "/users/123", router), "The show handler was called.")
+	assert.Contains(t, runRequest(http.MethodGet, "/users/new", router), "The slug handler was called with new.")
+	assert.Contains(t, runRequest(http.MethodGet, "/users/NEW1", router), "404 page not found")
+}
+
+func TestStaticRoutesAreReachableWhenRegisteredAfterNamedParams(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The show handler was called."))
+	}))
+	router.Get("/users/me", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The me handler was called."))
+	}))
+
+	assert.Contains(t, runRequest(http.MethodGet, "/users/me", router), "The me handler was called.")
+	assert.Contains(t, runRequest(http.MethodGet, "/users/1", router), "The show handler was called.")
+}
+
+func TestHostParamsAreAddedToRouteParams(t *testing.T) {
+	var params map[string]string
+
+	router := NewRouter()
+	router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		params = Params(r)
+	})).Host("{tenant}.example.com")
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "http://acme.example.com/users/4", nil))
+
+	assert.Equal(t, map[string]string{"tenant": "acme", "id": "4"}, params)
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "http://example.com/users/4", nil))
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestHostMatchersOnGroupsCaptureParams(t *testing.T) {
+	var tenant string
+
+	router := NewRouter()
+	router.Group("/", func(group Router) {
+		group.AddMatcher(NewHostMatcher("{tenant}.example.com"))
+		group.Get("/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			tenant = Param(r, "tenant")
+		}))
+	})
+
+	router.Dispatch(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://acme.example.com/", nil))
+
+	assert.Equal(t, "acme", tenant)
+}
+
+func TestBranchesWhoseRoutesRejectTheRequestDoNotHideOtherRoutes(t *testing.T) {
+	for _, compile := range []bool{false, true} {
+		router := NewRouter()
+		router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("user " + Param(r, "id")))
+		}))
+		router.Post("/users/me", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("posted"))
+		}))
+		router.Get("/about", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("admin about"))
+		})).Host("admin.example.com")
+		router.Get("/:page", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("page " + Param(r, "page")))
+		}))
+
+		if compile {
+			router.Compile()
+		}
+
+		assert.Equal(t, "user me", runRequest(http.MethodGet, "/users/me", router))
+		assert.Equal(t, "posted", runRequest(http.MethodPost, "/users/me", router))
+		assert.Equal(t, "admin about", runRequest(http.MethodGet, "http://admin.example.com/about", router))
+		assert.Equal(t, "page about", runRequest(http.MethodGet, "http://example.com/about", router))
+	}
+}
+
+func TestAllowHeaderListsTheMethodsOfEveryBranchThatMatchesThePath(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.Post("/users/me", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodDelete, "/users/me", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "POST, OPTIONS, GET, HEAD", response.Header().Get("Allow"))
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodOptions, "/users/me", nil))
+
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Equal(t, "POST, OPTIONS, GET, HEAD", response.Header().Get("Allow"))
+}
+
+func TestNonCanonicalPathsAreCleanedByDefault(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The users handler was called."))
+	}))
+
+	assert.Contains(t, runRequest(http.MethodGet, "/users/", router), "The users handler was called.")
+	assert.Contains(t, runRequest(http.MethodGet, "//users", router), "The users handler was called.")
+}
+
+func TestStrictPathPolicyMatchesThePathExactly(t *testing.T) {
+	router := NewRouter()
+	router.SetPathPolicy(PathStrict)
+	router.Get("/users", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The users handler was called."))
+	}))
+	router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+
+	assert.Contains(t, runRequest(http.MethodGet, "/users", router), "The users handler was called.")
+
+	for _, target := range []string{"/users/", "//users", "/users/../users"} {
+		response := httptest.NewRecorder()
+		router.Dispatch(response, httptest.NewRequest(http.MethodGet, target, nil))
+
+		assert.Equal(t, http.StatusNotFound, response.Code, target)
+	}
+}
+
+func TestRedirectPathPolicyRedirectsToTheCanonicalPath(t *testing.T) {
+	router := NewRouter()
+	router.SetPathPolicy(PathRedirect)
+	router.Match("/users", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The users handler was called."))
+	}), http.MethodGet, http.MethodPost)
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/users/?page=2", nil))
+
+	assert.Equal(t, http.StatusMovedPermanently, response.Code)
+	assert.Equal(t, "/users?page=2", response.Header().Get("Location"))
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodPost, "//users", nil))
+
+	assert.Equal(t, http.StatusPermanentRedirect, response.Code)
+	assert.Equal(t, "/users", response.Header().Get("Location"))
+
+	assert.Contains(t, runRequest(http.MethodGet, "/users", router), "The users handler was called.")
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/posts/", nil))
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestRoutesRegisteredWithATrailingSlashKeepIt(t *testing.T) {
+	router := NewRouter()
+	router.Get("/docs/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The docs handler was called."))
+	}))
+	router.Group("/api/", func(group Router) {
+		group.Get("/v1/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+		group.Get("/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	})
+
+	paths := []string{}
+	router.Collection().Each(func(route *Route) bool {
+		paths = append(paths, route.Path())
+		return true
+	})
+
+	assert.Equal(t, []string{"/docs/", "/api/v1/", "/api"}, paths)
+	assert.Contains(t, runRequest(http.MethodGet, "/docs/", router), "The docs handler was called.")
+	assert.Contains(t, runRequest(http.MethodGet, "/docs", router), "The docs handler was called.")
+}
+
+func TestStrictPathPolicyMatchesRoutesWithATrailingSlash(t *testing.T) {
+	router := NewRouter()
+	router.SetPathPolicy(PathStrict)
+	router.Get("/docs/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The docs handler was called."))
+	}))
+
+	assert.Contains(t, runRequest(http.MethodGet, "/docs/", router), "The docs handler was called.")
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestRedirectPathPolicyRedirectsToRoutesWithATrailingSlash(t *testing.T) {
+	router := NewRouter()
+	router.SetPathPolicy(PathRedirect)
+	router.Get("/docs/", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("The docs handler was called."))
+	}))
+
+	assert.Contains(t, runRequest(http.MethodGet, "/docs/", router), "The docs handler was called.")
+
+	for _, target := range []string{"/docs?page=2", "//docs?page=2", "/docs//?page=2"} {
+		response := httptest.NewRecorder()
+		router.Dispatch(response, httptest.NewRequest(http.MethodGet, target, nil))
+
+		assert.Equal(t, http.StatusMovedPermanently, response.Code, target)
+		assert.Equal(t, "/docs/?page=2", response.Header().Get("Location"), target)
+	}
+}
+
+func TestCaseInsensitiveMatchingServesFixedCasePaths(t *testing.T) {
+	var id string
+
+	router := NewRouter()
+	router.Get("/users/:id/Posts", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		id = Param(r, "id")
+	}))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/USERS/Bob/posts", nil))
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	router.SetCaseInsensitive(true)
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/USERS/Bob/posts", nil))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "Bob", id)
+}
+
+func TestCaseInsensitiveMatchingRedirectsWithTheRedirectPathPolicy(t *testing.T) {
+	router := NewRouter()
+	router.SetPathPolicy(PathRedirect)
+	router.SetCaseInsensitive(true)
+	router.Get("/users/:id/Posts", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.Get("/files/*filepath", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/USERS/Bob/posts/?tab=1", nil))
+
+	assert.Equal(t, http.StatusMovedPermanently, response.Code)
+	assert.Equal(t, "/users/Bob/Posts?tab=1", response.Header().Get("Location"))
+
+	response = httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodGet, "/Files/CSS/App.css", nil))
+
+	assert.Equal(t, "/files/CSS/App.css", response.Header().Get("Location"))
+}
+
 func TestStatusOKIsReturnedByDefault(t *testing.T) {
 	routeCollection := NewRouteCollection()
 	routeCollection.Add(NewRoute("/test", nil, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
@@ -91,11 +668,11 @@ func TestMultipleFoundRoutesCanBeFilteredByMethod(t *testing.T) {
 	assert.Contains(t, response.Body.String(), "The Handler Was Called For POST!")
 }
 
-func TestIfMultipleRoutesMatchedTheFirstFoundIsReturned(t *testing.T) {
+func TestRoutesWithTheSamePathAreDistinguishedByMatchers(t *testing.T) {
 	routeCollection := NewRouteCollection()
 	routeCollection.Add(NewRoute("/test", []string{http.MethodGet}, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 		rw.Write([]byte("The Handler For Route1 Was Called!"))
-	})))
+	})).AddMatcher(NewHeaderMatcher("X-Version", "1")))
 	routeCollection.Add(NewRoute("/test", []string{http.MethodGet}, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 		rw.Write([]byte("The Handler For Route2 Was Called!"))
 	})))
@@ -104,9 +681,54 @@ func TestIfMultipleRoutesMatchedTheFirstFoundIsReturned(t *testing.T) {
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest(http.MethodGet, "/test", nil)
+	request.Header.Set("X-Version", "1")
 
 	router.Dispatch(response, request)
 	assert.Contains(t, response.Body.String(), "The Handler For Route1 Was Called!")
+
+	assert.Contains(t, runRequest(http.MethodGet, "/test", router), "The Handler For Route2 Was Called!")
+}
+
+func TestRegistrationHelpersPanicWhenNamesConflict(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {})).Named("users.show")
+
+	assert.Panics(t, func() {
+		router.Post("/users", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {})).Named("users.show")
+	})
+}
+
+func TestValidateReportsConflictingRoutes(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	router.Match("/users/:name", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}), http.MethodGet, http.MethodPost)
+
+	assert.EqualError(
+		t,
+		router.Validate(),
+		"Route GET|POST /users/:name overlaps with existing route GET /users/:id.",
+	)
+}
+
+func TestGeneralRoutesCanBeRegisteredBeforeMoreSpecificRoutes(t *testing.T) {
+	router := NewRouter()
+	router.Get("/x", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("general"))
+	}))
+	router.Get("/x", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("b.com"))
+	})).Host("b.com")
+	router.Get("/u/:name", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("name"))
+	}))
+	router.Get("/u/:id", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("id"))
+	})).Where("id", "[0-9]+")
+
+	assert.NoError(t, router.Validate())
+	assert.Equal(t, "general", runRequest(http.MethodGet, "http://a.com/x", router))
+	assert.Equal(t, "id", runRequest(http.MethodGet, "/u/42", router))
+	assert.Equal(t, "name", runRequest(http.MethodGet, "/u/nick", router))
 }
 
 func TestRequestMethodHelperFunctions(t *testing.T) {
@@ -151,6 +773,7 @@ func TestRequestMethodHelperFunctions(t *testing.T) {
 	assert.Contains(t, runRequest(http.MethodPut, "/any", router), "The Any handler was called.")
 	assert.Contains(t, runRequest(http.MethodPatch, "/any", router), "The Any handler was called.")
 	assert.Contains(t, runRequest(http.MethodDelete, "/any", router), "The Any handler was called.")
+	assert.Contains(t, runRequest(http.MethodOptions, "/any", router), "The Any handler was called.")
 
 	assert.Contains(t, runRequest(http.MethodGet, "/match", router), "The Match handler was called.")
 	assert.Contains(t, runRequest(http.MethodPost, "/match", router), "The Match handler was called.")
@@ -0,0 +1,110 @@
+package routing
+
+import (
+	"regexp"
+	"strings"
+)
+
+// pathSegment is a parsed part of a route path. Segments are either static text, named params or a wildcard.
+// Named params are written as :name, {name} or {name:pattern}, where pattern is a regular expression that the
+// whole value of the segment must match. A wildcard is written as *name and captures the remainder of the path,
+// including slashes, so it can only be used as the last segment.
+type pathSegment struct {
+	// The segment as it was written in the route path.
+	raw string
+
+	// The param name for named params or the static text for static segments.
+	value string
+
+	isNamedParam bool
+	isWildcard   bool
+
+	// Only set for named params that have been given a pattern.
+	constraint *regexp.Regexp
+}
+
+// parsePath splits the route path into its segments. It panics if a wildcard is not the last segment.
+func parsePath(path string) []pathSegment {
+	parts := strings.Split(path, "/")[1:]
+	segments := make([]pathSegment, len(parts))
+
+	for i, part := range parts {
+		segments[i] = parseSegment(part)
+
+		if segments[i].isWildcard && i != len(parts)-1 {
+			panic("Wildcard " + part + " must be the last segment of route " + path + ".")
+		}
+	}
+
+	return segments
+}
+
+// parseSegment parses a single segment of a route path. It panics if a pattern cannot be compiled.
+func parseSegment(raw string) pathSegment {
+	segment := pathSegment{raw: raw, value: raw}
+
+	switch {
+	case strings.HasPrefix(raw, "*"):
+		segment.isWildcard = true
+		segment.value = raw[1:]
+	case strings.HasPrefix(raw, ":"):
+		segment.isNamedParam = true
+		segment.value = raw[1:]
+	case strings.HasPrefix(raw, "{") && strings.HasSuffix(raw, "}"):
+		segment.isNamedParam = true
+		segment.value = raw[1 : len(raw)-1]
+
+		if i := strings.Index(segment.value, ":"); i != -1 {
+			segment.constraint = regexp.MustCompile("^(?:" + segment.value[i+1:] + ")$")
+			segment.value = segment.value[:i]
+		}
+	}
+
+	return segment
+}
+
+// matches checks if the value from a request path can be matched by the segment. Named params never match an
+// empty value.
+func (segment pathSegment) matches(value string) bool {
+	switch {
+	case segment.isWildcard:
+		return true
+	case segment.isNamedParam:
+		return value != "" && (segment.constraint == nil || segment.constraint.MatchString(value))
+	default:
+		return segment.value == value
+	}
+}
+
+// matchesFold checks if the value matches a static segment when compared case insensitively.
+func (segment pathSegment) matchesFold(value string) bool {
+	return !segment.isNamedParam && !segment.isWildcard && strings.EqualFold(segment.value, value)
+}
+
+// precedence is used to order segments when searching, lower values are tried first.
+func (segment pathSegment) precedence() int {
+	switch {
+	case segment.isWildcard:
+		return 3
+	case segment.isNamedParam && segment.constraint == nil:
+		return 2
+	case segment.isNamedParam:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// shape describes what the segment matches without the param name, so that :id and :name have the same shape.
+func (segment pathSegment) shape() string {
+	switch {
+	case segment.isWildcard:
+		return "*"
+	case segment.isNamedParam && segment.constraint != nil:
+		return "{" + segment.constraint.String() + "}"
+	case segment.isNamedParam:
+		return ":"
+	default:
+		return segment.value
+	}
+}
@@ -0,0 +1,67 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticSegmentsAreParsed(t *testing.T) {
+	segment := parseSegment("users")
+
+	assert.False(t, segment.isNamedParam)
+	assert.Equal(t, "users", segment.value)
+	assert.True(t, segment.matches("users"))
+	assert.False(t, segment.matches("posts"))
+}
+
+func TestNamedParamSegmentsAreParsed(t *testing.T) {
+	for _, raw := range []string{":id", "{id}"} {
+		segment := parseSegment(raw)
+
+		assert.True(t, segment.isNamedParam, raw)
+		assert.Equal(t, "id", segment.value, raw)
+		assert.Nil(t, segment.constraint, raw)
+		assert.True(t, segment.matches("anything"), raw)
+	}
+}
+
+func TestConstrainedNamedParamSegmentsAreParsed(t *testing.T) {
+	segment := parseSegment("{id:[0-9]{2,3}}")
+
+	assert.True(t, segment.isNamedParam)
+	assert.Equal(t, "id", segment.value)
+	assert.True(t, segment.matches("123"))
+	assert.False(t, segment.matches("1"))
+	assert.False(t, segment.matches("1234"))
+	assert.False(t, segment.matches("abc"))
+}
+
+func TestParsePathSplitsSegments(t *testing.T) {
+	segments := parsePath("/users/{id:[0-9]+}")
+
+	assert.Len(t, segments, 2)
+	assert.Equal(t, "users", segments[0].raw)
+	assert.Equal(t, "{id:[0-9]+}", segments[1].raw)
+}
+
+func TestWildcardSegmentsAreParsed(t *testing.T) {
+	segment := parseSegment("*path")
+
+	assert.True(t, segment.isWildcard)
+	assert.False(t, segment.isNamedParam)
+	assert.Equal(t, "path", segment.value)
+	assert.True(t, segment.matches("anything"))
+}
+
+func TestParsePathPanicsWhenWildcardIsNotLast(t *testing.T) {
+	assert.Panics(t, func() {
+		parsePath("/files/*path/edit")
+	})
+}
+
+func TestSegmentPrecedence(t *testing.T) {
+	assert.True(t, parseSegment("static").precedence() < parseSegment("{id:[0-9]+}").precedence())
+	assert.True(t, parseSegment("{id:[0-9]+}").precedence() < parseSegment(":id").precedence())
+	assert.True(t, parseSegment(":id").precedence() < parseSegment("*path").precedence())
+}
@@ -0,0 +1,182 @@
+package routing
+
+import (
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// StaticHandler serves files from a directory on disk. The file is taken from the filepath wildcard param when
+// the handler is registered with Router.Static, otherwise the request path is used. Directory listings, hidden
+// files and paths that escape the root directory are never served. Conditional requests (ETag and Last-Modified)
+// and range requests are supported through http.ServeContent.
+type StaticHandler struct {
+	root          string
+	precompressed bool
+}
+
+// NewStaticHandler will create a StaticHandler that serves files from the root directory.
+func NewStaticHandler(root string) *StaticHandler {
+	return &StaticHandler{root: root}
+}
+
+// SetPrecompressed enables serving precompressed .br and .gz variants of a file, when they exist on disk next
+// to the original, to clients that accept those encodings.
+func (handler *StaticHandler) SetPrecompressed(precompressed bool) {
+	handler.precompressed = precompressed
+}
+
+// ServeHTTP will serve the requested file or respond with not found.
+func (handler *StaticHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	name, ok := Params(request)["filepath"]
+	if !ok {
+		name = request.URL.Path
+	}
+
+	file, ok := handler.resolve(name)
+	if !ok {
+		http.NotFound(response, request)
+		return
+	}
+
+	handler.serveFile(response, request, file)
+}
+
+// Fallback returns middleware for Router.UseFallback that serves the file for GET and HEAD requests below the
+// path prefix when it exists, so that routes always take precedence over files. Requests for any other path,
+// or for files that do not exist, are passed to the next handler.
+func (handler *StaticHandler) Fallback(prefix string) Middleware {
+	prefix = strings.TrimRight("/"+strings.Trim(strings.TrimSpace(prefix), "/"), "/")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			if request.Method != http.MethodGet && request.Method != http.MethodHead {
+				next.ServeHTTP(response, request)
+				return
+			}
+
+			if !strings.HasPrefix(request.URL.Path, prefix+"/") {
+				next.ServeHTTP(response, request)
+				return
+			}
+
+			file, ok := handler.resolve(strings.TrimPrefix(request.URL.Path, prefix))
+			if !ok {
+				next.ServeHTTP(response, request)
+				return
+			}
+
+			if info, err := os.Stat(file); err != nil || !info.Mode().IsRegular() {
+				next.ServeHTTP(response, request)
+				return
+			}
+
+			handler.serveFile(response, request, file)
+		})
+	}
+}
+
+// resolve converts the requested name into a path within the root directory. Names that contain hidden
+// segments or that would escape the root are rejected.
+func (handler *StaticHandler) resolve(name string) (string, bool) {
+	if strings.ContainsAny(name, "\\\x00") {
+		return "", false
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return "", false
+		}
+	}
+
+	cleaned := path.Clean("/" + name)
+	if cleaned == "/" {
+		return "", false
+	}
+
+	return filepath.Join(handler.root, filepath.FromSlash(cleaned)), true
+}
+
+// serveFile writes the file, or a precompressed variant of it, to the response.
+func (handler *StaticHandler) serveFile(response http.ResponseWriter, request *http.Request, file string) {
+	if handler.precompressed {
+		response.Header().Add("Vary", "Accept-Encoding")
+
+		for _, variant := range []struct{ encoding, extension string }{{"br", ".br"}, {"gzip", ".gz"}} {
+			if !acceptsEncoding(request, variant.encoding) {
+				continue
+			}
+
+			if handler.serveRegularFile(response, request, file+variant.extension, file, variant.encoding) {
+				return
+			}
+		}
+	}
+
+	if !handler.serveRegularFile(response, request, file, file, "") {
+		http.NotFound(response, request)
+	}
+}
+
+// serveRegularFile serves the file at name if it is a regular file. The content type is taken from original
+// so that precompressed variants are served with the type of the file they were compressed from.
+func (handler *StaticHandler) serveRegularFile(response http.ResponseWriter, request *http.Request, name, original, encoding string) bool {
+	f, err := os.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+
+	etag := strconv.FormatInt(info.ModTime().UnixNano(), 16) + "-" + strconv.FormatInt(info.Size(), 16)
+
+	if encoding != "" {
+		contentType := mime.TypeByExtension(filepath.Ext(original))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		response.Header().Set("Content-Type", contentType)
+		response.Header().Set("Content-Encoding", encoding)
+		etag += "-" + encoding
+	}
+
+	response.Header().Set("ETag", `"`+etag+`"`)
+
+	http.ServeContent(response, request, original, info.ModTime(), f)
+
+	return true
+}
+
+// acceptsEncoding checks the Accept-Encoding header of the request for the encoding, ignoring encodings
+// that have been given a quality of zero.
+func acceptsEncoding(request *http.Request, encoding string) bool {
+	for _, header := range request.Header["Accept-Encoding"] {
+		for _, part := range strings.Split(header, ",") {
+			fields := strings.Split(part, ";")
+
+			if !strings.EqualFold(strings.TrimSpace(fields[0]), encoding) {
+				continue
+			}
+
+			for _, param := range fields[1:] {
+				param = strings.Replace(strings.TrimSpace(param), " ", "", -1)
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); strings.HasPrefix(param, "q=") && err == nil && q == 0 {
+					return false
+				}
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
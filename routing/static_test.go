@@ -0,0 +1,173 @@
+package routing
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createStaticRoot(t *testing.T, files map[string]string) string {
+	root, err := ioutil.TempDir("", "gimli-static")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	for name, contents := range files {
+		file := filepath.Join(root, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+
+		if err := ioutil.WriteFile(file, []byte(contents), 0644); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	return root
+}
+
+func runStaticRequest(router Router, path string, headers map[string]string) *httptest.ResponseRecorder {
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	router.Dispatch(response, request)
+
+	return response
+}
+
+func TestStaticServesFilesUnderPrefix(t *testing.T) {
+	root := createStaticRoot(t, map[string]string{"css/app.css": "body {}"})
+	defer os.RemoveAll(root)
+
+	router := NewRouter()
+	router.Static("/assets", root)
+
+	response := runStaticRequest(router, "/assets/css/app.css", nil)
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "body {}", response.Body.String())
+	assert.Contains(t, response.Header().Get("Content-Type"), "text/css")
+	assert.NotEmpty(t, response.Header().Get("ETag"))
+	assert.NotEmpty(t, response.Header().Get("Last-Modified"))
+}
+
+func TestStaticHandlerUsesRequestPathWithoutWildcard(t *testing.T) {
+	root := createStaticRoot(t, map[string]string{"robots.txt": "User-agent: *"})
+	defer os.RemoveAll(root)
+
+	router := NewRouter()
+	router.Get("/robots.txt", NewStaticHandler(root))
+
+	assert.Equal(t, "User-agent: *", runStaticRequest(router, "/robots.txt", nil).Body.String())
+}
+
+func TestStaticHandlerFallbackServesFilesWhenNoRouteMatches(t *testing.T) {
+	root := createStaticRoot(t, map[string]string{"app.css": "body {}", "robots.txt": "User-agent: *", ".secret": "secret"})
+	defer os.RemoveAll(root)
+
+	router := NewRouter()
+	router.Get("/assets/robots.txt", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("Route"))
+	}))
+	router.UseFallback(NewStaticHandler(root).Fallback("/assets"))
+
+	assert.Equal(t, "body {}", runStaticRequest(router, "/assets/app.css", nil).Body.String())
+	assert.Equal(t, "Route", runStaticRequest(router, "/assets/robots.txt", nil).Body.String())
+	assert.Equal(t, http.StatusNotFound, runStaticRequest(router, "/app.css", nil).Code)
+	assert.Equal(t, http.StatusNotFound, runStaticRequest(router, "/assets/missing.css", nil).Code)
+	assert.Equal(t, http.StatusNotFound, runStaticRequest(router, "/assets/.secret", nil).Code)
+	assert.Equal(t, http.StatusNotFound, runStaticRequest(router, "/assets/", nil).Code)
+
+	response := httptest.NewRecorder()
+	router.Dispatch(response, httptest.NewRequest(http.MethodPost, "/assets/app.css", nil))
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestStaticDoesNotListDirectoriesOrServeHiddenFiles(t *testing.T) {
+	root := createStaticRoot(t, map[string]string{"css/app.css": "body {}", "css/.secret": "secret"})
+	defer os.RemoveAll(root)
+
+	router := NewRouter()
+	router.Static("/assets", root)
+
+	assert.Equal(t, http.StatusNotFound, runStaticRequest(router, "/assets/css", nil).Code)
+	assert.Equal(t, http.StatusNotFound, runStaticRequest(router, "/assets/css/.secret", nil).Code)
+	assert.Equal(t, http.StatusNotFound, runStaticRequest(router, "/assets/missing.css", nil).Code)
+}
+
+func TestStaticHandlerDoesNotEscapeRoot(t *testing.T) {
+	handler := NewStaticHandler("/srv/public")
+
+	file, ok := handler.resolve("/css//app.css")
+	assert.True(t, ok)
+	assert.Equal(t, filepath.FromSlash("/srv/public/css/app.css"), file)
+
+	_, ok = handler.resolve("css/../../etc/passwd")
+	assert.False(t, ok)
+
+	_, ok = handler.resolve("..\\..\\etc\\passwd")
+	assert.False(t, ok)
+
+	_, ok = handler.resolve("../")
+	assert.False(t, ok)
+}
+
+func TestStaticSupportsConditionalAndRangeRequests(t *testing.T) {
+	root := createStaticRoot(t, map[string]string{"file.txt": "0123456789"})
+	defer os.RemoveAll(root)
+
+	router := NewRouter()
+	router.Static("/assets", root)
+
+	etag := runStaticRequest(router, "/assets/file.txt", nil).Header().Get("ETag")
+
+	response := runStaticRequest(router, "/assets/file.txt", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, response.Code)
+
+	response = runStaticRequest(router, "/assets/file.txt", map[string]string{"Range": "bytes=2-4"})
+	assert.Equal(t, http.StatusPartialContent, response.Code)
+	assert.Equal(t, "234", response.Body.String())
+}
+
+func TestStaticServesPrecompressedVariantsWhenEnabled(t *testing.T) {
+	root := createStaticRoot(t, map[string]string{
+		"app.js":    "plain",
+		"app.js.gz": "gzipped",
+		"app.js.br": "brotli",
+	})
+	defer os.RemoveAll(root)
+
+	handler := NewStaticHandler(root)
+
+	router := NewRouter()
+	router.Get("/assets/*filepath", handler)
+
+	response := runStaticRequest(router, "/assets/app.js", map[string]string{"Accept-Encoding": "gzip, br"})
+	assert.Equal(t, "plain", response.Body.String())
+
+	handler.SetPrecompressed(true)
+
+	response = runStaticRequest(router, "/assets/app.js", map[string]string{"Accept-Encoding": "gzip, br"})
+	assert.Equal(t, "brotli", response.Body.String())
+	assert.Equal(t, "br", response.Header().Get("Content-Encoding"))
+	assert.Contains(t, response.Header().Get("Content-Type"), "javascript")
+	assert.Equal(t, "Accept-Encoding", response.Header().Get("Vary"))
+
+	response = runStaticRequest(router, "/assets/app.js", map[string]string{"Accept-Encoding": "gzip, br;q=0"})
+	assert.Equal(t, "gzipped", response.Body.String())
+	assert.Equal(t, "gzip", response.Header().Get("Content-Encoding"))
+
+	response = runStaticRequest(router, "/assets/app.js", nil)
+	assert.Equal(t, "plain", response.Body.String())
+	assert.Empty(t, response.Header().Get("Content-Encoding"))
+}
@@ -8,115 +8,205 @@ import (
 // defined below.
 type routeTrie interface {
 	add(route *Route)
+	remove(route *Route)
 	search(path string) ([]*Route, map[string]string)
+	searchFold(path string) ([]*Route, map[string]string)
+	searchWhere(path string, fold bool, accept func(routes []*Route) bool) ([]*Route, map[string]string)
+	searchAll(path string, fold bool) []*Route
 }
 
 // trie is a simple trie data structure that allows for multiple routes to be added
 // to each node. The trie should be used to get all routes that match a path, any
-// matching on methods, regex, host, scheme etc should be handled elsewhere.
+// matching on methods, host, scheme etc should be handled elsewhere. Param constraints
+// are part of the segment and are checked while traversing.
 type trie struct {
 	// Each node can have multiple children
 	// (ie. /some/path and /some/thing, "some" would have "path" and "thing" child node).
 	children []*trie
 
 	// The part of the path (if path was /some/path, "some" and "path" would be a segment).
-	segment string
+	segment pathSegment
 
-	// Used to check if segment should be added to params when looking up a route.
-	isNamedParam bool
-
-	// Routes will be set on leaf nodes only.
+	// Routes will be set on the node for the last segment of their path only.
 	routes []*Route
 }
 
 // newRouteTrie will return a new instance of a trie that implements the routeTrie interface.
-// It also sets the first segment to / (this can be overridden by adding a route with "/" as the path.
+// The root node represents the leading / of every path.
 func newRouteTrie() routeTrie {
-	return &trie{segment: "/", isNamedParam: false, routes: nil}
+	return &trie{segment: pathSegment{raw: "/", value: "/"}, routes: nil}
 }
 
-// add will insert a new route into the trie.
+// add will insert a new route into the trie. Nodes are shared between routes whose segments
+// are written in exactly the same way.
 func (t *trie) add(route *Route) {
-	segments := strings.Split(route.Path(), "/")[1:]
-	numSegments := len(segments)
-
-	for {
-		// Traverse the segments to see if we match, if we don't then the original
-		// trie and segment is returned.
-		node, segment := t.traverse(segments, nil)
-
-		// If we find a node that matches the last segment then update it and return.
-		if len(segments) > 0 && node.segment == segments[len(segments)-1] {
-			node.routes = append(node.routes, route)
-			return
-		}
+	node := t
 
-		newTrieNode := &trie{
-			segment:      segment,
-			isNamedParam: false,
-			routes:       nil,
-		}
+	for _, segment := range route.segments {
+		node = node.child(segment)
+	}
+
+	node.routes = append(node.routes, route)
+}
 
-		// Check for named route.
-		if len(segment) > 0 && segment[0] == ':' {
-			newTrieNode.isNamedParam = true
+// child returns the child node for the segment, creating it if it does not exist. Children are
+// kept ordered by precedence so that static segments are tried before constrained named params,
+// which are tried before unconstrained named params and finally wildcards. Children with the same
+// precedence keep the order that they were added in.
+func (t *trie) child(segment pathSegment) *trie {
+	for _, child := range t.children {
+		if child.segment.raw == segment.raw {
+			return child
 		}
+	}
+
+	child := &trie{segment: segment}
+
+	i := len(t.children)
+	for i > 0 && t.children[i-1].segment.precedence() > segment.precedence() {
+		i--
+	}
+
+	t.children = append(t.children, nil)
+	copy(t.children[i+1:], t.children[i:])
+	t.children[i] = child
+
+	return child
+}
+
+// remove will take the route out of the node for its path. Nodes that are left without
+// routes or children are pruned so that they can not be reached when searching.
+func (t *trie) remove(route *Route) {
+	t.removeSegments(route, route.segments)
+}
 
-		// If the last node is the segment before the one we are adding then add to its child.
-		if len(segments) > 1 && node.segment == segments[len(segments)-2] {
-			newTrieNode.routes = append(newTrieNode.routes, route)
-			node.children = append(node.children, newTrieNode)
-			return
+// removeSegments walks down to the node for the remaining segments and removes the route.
+func (t *trie) removeSegments(route *Route, segments []pathSegment) {
+	if len(segments) == 0 {
+		for i, existing := range t.routes {
+			if existing == route {
+				t.routes = append(t.routes[:i:i], t.routes[i+1:]...)
+				return
+			}
 		}
 
-		// This is the last segment of the path so add the route.
-		if numSegments == 1 {
-			newTrieNode.routes = append(newTrieNode.routes, route)
+		return
+	}
+
+	for i, child := range t.children {
+		if child.segment.raw != segments[0].raw {
+			continue
 		}
 
-		// Add a new child to the current node.
-		node.children = append(node.children, newTrieNode)
-		numSegments--
+		child.removeSegments(route, segments[1:])
 
-		// Once we have gone through all the segments we are not updating
-		// and have added a node for every segment in the path.
-		if numSegments == 0 {
-			break
+		if len(child.routes) == 0 && len(child.children) == 0 {
+			t.children = append(t.children[:i:i], t.children[i+1:]...)
 		}
+
+		return
 	}
 }
 
 // search will traverse the trie looking for a match to the path.
 // If nothing is found it will return (nil, map[string]string{}).
 func (t *trie) search(path string) ([]*Route, map[string]string) {
+	return t.find(path, false, nil)
+}
+
+// searchFold works like search but compares static segments case insensitively.
+func (t *trie) searchFold(path string) ([]*Route, map[string]string) {
+	return t.find(path, true, nil)
+}
+
+// searchWhere works like search but skips nodes whose routes are not accepted, so that the search carries on
+// with the sibling branches that also match the path.
+func (t *trie) searchWhere(path string, fold bool, accept func(routes []*Route) bool) ([]*Route, map[string]string) {
+	return t.find(path, fold, accept)
+}
+
+// searchAll returns the routes of every node that matches the path, in order of precedence.
+func (t *trie) searchAll(path string, fold bool) []*Route {
+	routes := []*Route{}
+	t.collect(strings.Split(path, "/")[1:], fold, &routes)
+
+	return routes
+}
+
+// find starts the traversal of the trie for search, searchFold and searchWhere.
+func (t *trie) find(path string, fold bool, accept func(routes []*Route) bool) ([]*Route, map[string]string) {
 	params := map[string]string{}
 
-	node, _ := t.traverse(strings.Split(path, "/")[1:], params)
+	node := t.traverse(strings.Split(path, "/")[1:], params, fold, accept)
+	if node == nil {
+		return nil, map[string]string{}
+	}
 
 	return node.routes, params
 }
 
 // traverse recursively searches the trie based on the path segments and extracts
-// named params along the way.
-func (t *trie) traverse(segments []string, params map[string]string) (*trie, string) {
+// named params along the way. Children are tried in order of precedence and any child
+// whose constraint is not met by the segment, or that does not lead to an accepted route,
+// is skipped so that its siblings can be tried. Wildcards capture all of the remaining segments.
+func (t *trie) traverse(segments []string, params map[string]string, fold bool, accept func(routes []*Route) bool) *trie {
+	if len(segments) == 0 {
+		if !t.accepts(accept) {
+			return nil
+		}
+
+		return t
+	}
+
 	segment := segments[0]
 
-	if len(t.children) > 0 {
-		for _, child := range t.children {
-			if segment == child.segment || child.isNamedParam {
-				if child.isNamedParam && params != nil {
-					params[child.segment[1:]] = segment
-				}
+	for _, child := range t.children {
+		if child.segment.isWildcard {
+			if !child.accepts(accept) {
+				continue
+			}
+
+			params[child.segment.value] = strings.Join(segments, "/")
+			return child
+		}
 
-				next := segments[1:]
-				if len(next) > 0 {
-					return child.traverse(next, params)
-				}
+		if !child.segment.matches(segment) && !(fold && child.segment.matchesFold(segment)) {
+			continue
+		}
 
-				return child, segment
+		if node := child.traverse(segments[1:], params, fold, accept); node != nil {
+			if child.segment.isNamedParam {
+				params[child.segment.value] = segment
 			}
+
+			return node
 		}
 	}
 
-	return t, segment
+	return nil
+}
+
+// accepts checks that the node has routes and that they are accepted, every node with routes is accepted
+// when accept is nil.
+func (t *trie) accepts(accept func(routes []*Route) bool) bool {
+	return len(t.routes) > 0 && (accept == nil || accept(t.routes))
+}
+
+// collect appends the routes of every node below t that matches the path segments to routes.
+func (t *trie) collect(segments []string, fold bool, routes *[]*Route) {
+	if len(segments) == 0 {
+		*routes = append(*routes, t.routes...)
+		return
+	}
+
+	for _, child := range t.children {
+		if child.segment.isWildcard {
+			*routes = append(*routes, child.routes...)
+			continue
+		}
+
+		if child.segment.matches(segments[0]) || (fold && child.segment.matchesFold(segments[0])) {
+			child.collect(segments[1:], fold, routes)
+		}
+	}
 }
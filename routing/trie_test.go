@@ -71,3 +71,223 @@ func TestNamedParamsAreReturnedWhenRoutesAreFound(t *testing.T) {
 	_, params := trie.search("/path/to/route/nick/with/123")
 	assert.Equal(t, map[string]string{"name": "nick", "id": "123"}, params)
 }
+
+func TestRoutesAreNotMatchedWhenPathHasExtraSegments(t *testing.T) {
+	trie := newRouteTrie()
+	trie.add(NewRoute("/path/to/route", nil, nil))
+
+	routes, _ := trie.search("/path/to/route/extra")
+	assert.Nil(t, routes)
+}
+
+func TestSiblingNodesAreTriedWhenConstraintFails(t *testing.T) {
+	trie := newRouteTrie()
+
+	numeric := NewRoute("/users/{id:[0-9]+}", nil, nil)
+	named := NewRoute("/users/:name", nil, nil)
+
+	trie.add(numeric)
+	trie.add(named)
+
+	routes, params := trie.search("/users/123")
+	assert.Equal(t, []*Route{numeric}, routes)
+	assert.Equal(t, map[string]string{"id": "123"}, params)
+
+	routes, params = trie.search("/users/nick")
+	assert.Equal(t, []*Route{named}, routes)
+	assert.Equal(t, map[string]string{"name": "nick"}, params)
+}
+
+func TestRoutesCanBeRemoved(t *testing.T) {
+	trie := newRouteTrie()
+
+	route1 := NewRoute("/path/to/route", []string{"GET"}, nil)
+	route2 := NewRoute("/path/to/route", []string{"POST"}, nil)
+
+	trie.add(route1)
+	trie.add(route2)
+	trie.remove(route1)
+
+	routes, _ := trie.search("/path/to/route")
+	assert.Equal(t, []*Route{route2}, routes)
+
+	trie.remove(route2)
+
+	routes, _ = trie.search("/path/to/route")
+	assert.Nil(t, routes)
+}
+
+func TestWildcardCapturesRemainderOfPath(t *testing.T) {
+	trie := newRouteTrie()
+
+	route := NewRoute("/files/*path", nil, nil)
+	trie.add(route)
+
+	routes, params := trie.search("/files/css/app/main.css")
+	assert.Equal(t, []*Route{route}, routes)
+	assert.Equal(t, map[string]string{"path": "css/app/main.css"}, params)
+
+	routes, _ = trie.search("/files")
+	assert.Nil(t, routes)
+}
+
+func TestWildcardHasLowerPrecedenceThanStaticAndNamedNodes(t *testing.T) {
+	trie := newRouteTrie()
+
+	wildcard := NewRoute("/files/*path", nil, nil)
+	static := NewRoute("/files/index", nil, nil)
+	named := NewRoute("/files/:name/edit", nil, nil)
+
+	trie.add(wildcard)
+	trie.add(static)
+	trie.add(named)
+
+	routes, _ := trie.search("/files/index")
+	assert.Equal(t, []*Route{static}, routes)
+
+	routes, params := trie.search("/files/readme/edit")
+	assert.Equal(t, []*Route{named}, routes)
+	assert.Equal(t, map[string]string{"name": "readme"}, params)
+
+	routes, params = trie.search("/files/readme")
+	assert.Equal(t, []*Route{wildcard}, routes)
+	assert.Equal(t, map[string]string{"path": "readme"}, params)
+}
+
+func TestStaticNodesTakePrecedenceOverNamedParamsRegardlessOfOrder(t *testing.T) {
+	trie := newRouteTrie()
+
+	named := NewRoute("/users/:id", nil, nil)
+	static := NewRoute("/users/me", nil, nil)
+
+	trie.add(named)
+	trie.add(static)
+
+	routes, params := trie.search("/users/me")
+	assert.Equal(t, []*Route{static}, routes)
+	assert.Empty(t, params)
+
+	routes, params = trie.search("/users/123")
+	assert.Equal(t, []*Route{named}, routes)
+	assert.Equal(t, map[string]string{"id": "123"}, params)
+}
+
+func TestConstrainedParamsTakePrecedenceOverUnconstrainedParams(t *testing.T) {
+	trie := newRouteTrie()
+
+	unconstrained := NewRoute("/users/:name", nil, nil)
+	constrained := NewRoute("/users/{id:[0-9]+}", nil, nil)
+
+	trie.add(unconstrained)
+	trie.add(constrained)
+
+	routes, _ := trie.search("/users/123")
+	assert.Equal(t, []*Route{constrained}, routes)
+
+	routes, _ = trie.search("/users/nick")
+	assert.Equal(t, []*Route{unconstrained}, routes)
+}
+
+func TestSearchWhereSkipsBranchesWhoseRoutesAreNotAccepted(t *testing.T) {
+	trie := newRouteTrie()
+
+	static := NewRoute("/users/me", nil, nil)
+	named := NewRoute("/users/:id", nil, nil)
+	wildcard := NewRoute("/users/*rest", nil, nil)
+
+	trie.add(static)
+	trie.add(named)
+	trie.add(wildcard)
+
+	routes, params := trie.searchWhere("/users/me", false, func(routes []*Route) bool {
+		return routes[0] != static
+	})
+	assert.Equal(t, []*Route{named}, routes)
+	assert.Equal(t, map[string]string{"id": "me"}, params)
+
+	routes, params = trie.searchWhere("/users/me", false, func(routes []*Route) bool {
+		return routes[0] == wildcard
+	})
+	assert.Equal(t, []*Route{wildcard}, routes)
+	assert.Equal(t, map[string]string{"rest": "me"}, params)
+
+	assert.Equal(t, []*Route{static, named, wildcard}, trie.searchAll("/users/me", false))
+	assert.Equal(t, []*Route{named, wildcard}, trie.searchAll("/users/ME", false))
+	assert.Equal(t, []*Route{static, named, wildcard}, trie.searchAll("/users/ME", true))
+}
+
+func TestSearchBacktracksWhenStaticBranchIsADeadEnd(t *testing.T) {
+	trie := newRouteTrie()
+
+	static := NewRoute("/users/me/settings", nil, nil)
+	named := NewRoute("/users/:id/posts", nil, nil)
+
+	trie.add(static)
+	trie.add(named)
+
+	routes, params := trie.search("/users/me/posts")
+	assert.Equal(t, []*Route{named}, routes)
+	assert.Equal(t, map[string]string{"id": "me"}, params)
+
+	routes, params = trie.search("/users/me/settings")
+	assert.Equal(t, []*Route{static}, routes)
+	assert.Empty(t, params)
+}
+
+func TestSearchBacktracksWhenNamedBranchIsADeadEnd(t *testing.T) {
+	trie := newRouteTrie()
+
+	named := NewRoute("/:section/edit", nil, nil)
+	static := NewRoute("/about", nil, nil)
+	wildcard := NewRoute("/*path", nil, nil)
+
+	trie.add(named)
+	trie.add(static)
+	trie.add(wildcard)
+
+	routes, params := trie.search("/about")
+	assert.Equal(t, []*Route{static}, routes)
+	assert.Empty(t, params)
+
+	routes, params = trie.search("/contact")
+	assert.Equal(t, []*Route{wildcard}, routes)
+	assert.Equal(t, map[string]string{"path": "contact"}, params)
+
+	routes, params = trie.search("/about/edit")
+	assert.Equal(t, []*Route{named}, routes)
+	assert.Equal(t, map[string]string{"section": "about"}, params)
+}
+
+func TestParamsFromFailedBranchesAreNotReturned(t *testing.T) {
+	trie := newRouteTrie()
+
+	trie.add(NewRoute("/:a/:b/never", nil, nil))
+	route := NewRoute("/:c/:d", nil, nil)
+	trie.add(route)
+
+	routes, params := trie.search("/one/two")
+	assert.Equal(t, []*Route{route}, routes)
+	assert.Equal(t, map[string]string{"c": "one", "d": "two"}, params)
+}
+
+func TestSearchFoldComparesStaticSegmentsCaseInsensitively(t *testing.T) {
+	trie := newRouteTrie()
+
+	route := NewRoute("/Users/:name", nil, nil)
+	trie.add(route)
+
+	routes, _ := trie.search("/users/Bob")
+	assert.Empty(t, routes)
+
+	routes, params := trie.searchFold("/users/Bob")
+	assert.Equal(t, []*Route{route}, routes)
+	assert.Equal(t, map[string]string{"name": "Bob"}, params)
+}
+
+func TestNamedParamsDoNotMatchEmptySegments(t *testing.T) {
+	trie := newRouteTrie()
+	trie.add(NewRoute("/users/:id", nil, nil))
+
+	routes, _ := trie.search("/users/")
+	assert.Empty(t, routes)
+}
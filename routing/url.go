@@ -0,0 +1,87 @@
+package routing
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// URL builds a url for the route by replacing its named params with the given values. Param values are escaped
+// and must satisfy any constraints set on the route. If the route is restricted to a host the returned url will be
+// absolute, using the first scheme the route is restricted to or http, and the params are also used to fill in the
+// host pattern. Query values are appended when given.
+func (r *Route) URL(params map[string]string, query url.Values) (string, error) {
+	routeSegments := r.pathSegments()
+	segments := make([]string, len(routeSegments))
+
+	for i, segment := range routeSegments {
+		if !segment.isNamedParam && !segment.isWildcard {
+			segments[i] = segment.value
+			continue
+		}
+
+		value, ok := params[segment.value]
+		if !ok || value == "" {
+			return "", errors.New("Param " + segment.value + " is required to build a url for route " + r.Path() + ".")
+		}
+
+		if !segment.matches(value) {
+			return "", errors.New("Param " + segment.value + " does not match the constraint " + segment.constraint.String() + ".")
+		}
+
+		if segment.isWildcard {
+			segments[i] = escapeWildcard(value)
+			continue
+		}
+
+		segments[i] = url.PathEscape(value)
+	}
+
+	built := "/" + strings.Join(segments, "/")
+
+	r.mux.RLock()
+	hostMatcher, schemeMatcher := r.host, r.scheme
+	r.mux.RUnlock()
+
+	if hostMatcher != nil {
+		host, err := hostMatcher.build(params)
+		if err != nil {
+			return "", err
+		}
+
+		scheme := "http"
+		if schemeMatcher != nil && len(schemeMatcher.schemes) > 0 {
+			scheme = schemeMatcher.schemes[0]
+		}
+
+		built = scheme + "://" + host + built
+	}
+
+	if len(query) > 0 {
+		built += "?" + query.Encode()
+	}
+
+	return built, nil
+}
+
+// URL builds a url for the named route. An error is returned if the route does not exist or if the params
+// could not be used to build the url.
+func (r *router) URL(name string, params map[string]string, query url.Values) (string, error) {
+	route := r.collection.RouteByName(strings.ToLower(strings.TrimSpace(name)))
+	if route == nil {
+		return "", errors.New("Route " + name + " does not exist.")
+	}
+
+	return route.URL(params, query)
+}
+
+// escapeWildcard escapes each part of a wildcard value while keeping the slashes between them.
+func escapeWildcard(value string) string {
+	parts := strings.Split(strings.TrimLeft(value, "/"), "/")
+
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,99 @@
+package routing
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLReplacesNamedParams(t *testing.T) {
+	route := NewRoute("/users/:id/posts/:post", nil, nil)
+
+	built, err := route.URL(map[string]string{"id": "4", "post": "9"}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/users/4/posts/9", built)
+}
+
+func TestURLEscapesParamValues(t *testing.T) {
+	route := NewRoute("/search/:term", nil, nil)
+
+	built, err := route.URL(map[string]string{"term": "a b/c"}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/search/a%20b%2Fc", built)
+}
+
+func TestURLAppendsQueryValues(t *testing.T) {
+	route := NewRoute("/users", nil, nil)
+
+	built, err := route.URL(nil, url.Values{"page": {"2"}, "sort": {"name"}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/users?page=2&sort=name", built)
+}
+
+func TestURLReturnsErrorWhenParamIsMissing(t *testing.T) {
+	route := NewRoute("/users/:id/posts/:post", nil, nil)
+
+	_, err := route.URL(map[string]string{"id": "4"}, nil)
+
+	assert.EqualError(t, err, "Param post is required to build a url for route /users/:id/posts/:post.")
+}
+
+func TestURLReturnsErrorWhenParamDoesNotSatisfyConstraint(t *testing.T) {
+	route := NewRoute("/users/:id", nil, nil).Where("id", "[0-9]+")
+
+	_, err := route.URL(map[string]string{"id": "new"}, nil)
+
+	assert.EqualError(t, err, "Param id does not match the constraint ^(?:[0-9]+)$.")
+}
+
+func TestURLIsAbsoluteWhenRouteHasAHost(t *testing.T) {
+	route := NewRoute("/users/:id", nil, nil).Host("example.com")
+
+	built, _ := route.URL(map[string]string{"id": "4"}, nil)
+	assert.Equal(t, "http://example.com/users/4", built)
+
+	route.Scheme("https")
+
+	built, _ = route.URL(map[string]string{"id": "4"}, nil)
+	assert.Equal(t, "https://example.com/users/4", built)
+}
+
+func TestURLFillsHostParams(t *testing.T) {
+	route := NewRoute("/users/:id", nil, nil).Host("{tenant}.example.com").Scheme("https")
+
+	built, err := route.URL(map[string]string{"tenant": "acme", "id": "4"}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://acme.example.com/users/4", built)
+}
+
+func TestRouterURLBuildsUrlForNamedRoute(t *testing.T) {
+	router := NewRouter()
+	router.Get("/users/:id/posts/:post", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {})).
+		Named("users.posts.show")
+
+	built, err := router.URL("users.posts.show", map[string]string{"id": "4", "post": "9"}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/users/4/posts/9", built)
+}
+
+func TestRouterURLReturnsErrorWhenRouteDoesNotExist(t *testing.T) {
+	_, err := NewRouter().URL("missing", nil, nil)
+
+	assert.EqualError(t, err, "Route missing does not exist.")
+}
+
+func TestURLKeepsSlashesInWildcardValues(t *testing.T) {
+	route := NewRoute("/files/*path", nil, nil)
+
+	built, err := route.URL(map[string]string{"path": "/css/my app.css"}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "/files/css/my%20app.css", built)
+}